@@ -30,7 +30,44 @@ func (a AttributeType) String() string {
 	return out
 }
 
+// label is dictionary name of the attribute or String() of unknown one
+func (a AttributeType) label() string {
+	if name := a.Name(); name != "" {
+		return name
+	}
+	return a.String()
+}
+
 type AttributeValue uint32
+
+// EnumValue is implemented by named values of enumerated integer attributes
+type EnumValue interface {
+	fmt.Stringer
+	Uint32() uint32
+}
+
+// uint32Value returns integer of uint32, AttributeValue or EnumValue
+func uint32Value(v interface{}) (uint32, bool) {
+	switch i := v.(type) {
+	case uint32:
+		return i, true
+	case AttributeValue:
+		return uint32(i), true
+	case EnumValue:
+		return i.Uint32(), true
+	}
+	return 0, false
+}
+
+// Deprecated: use AcctStatusType values
+const (
+	Attr_AcctStatusType_Value_Start         = AttributeValue(AcctStatusType_Start)
+	Attr_AcctStatusType_Value_Stop          = AttributeValue(AcctStatusType_Stop)
+	Attr_AcctStatusType_Value_InterimUpdate = AttributeValue(AcctStatusType_InterimUpdate)
+	Attr_AcctStatusType_Value_AccountingOn  = AttributeValue(AcctStatusType_AccountingOn)
+	Attr_AcctStatusType_Value_AccountingOff = AttributeValue(AcctStatusType_AccountingOff)
+)
+
 type Attribute struct {
 	Type AttributeType
 	Wire []byte
@@ -103,9 +140,10 @@ func (a *Attribute) ValueString(v *string) error {
 	return nil
 }
 
+// ValueUint32 also accepts named values of enumerated attributes
 func (a *Attribute) ValueUint32(v *uint32) error {
 	var ok bool
-	if *v, ok = a.Value.(uint32); !ok {
+	if *v, ok = uint32Value(a.Value); !ok {
 		return fmt.Errorf("can't cast value of attribute %s as uint32", a.Type)
 	}
 	return nil
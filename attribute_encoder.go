@@ -5,7 +5,9 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"net"
+	"time"
 )
 
 type EncoderInterface interface {
@@ -21,7 +23,16 @@ type EncoderDefault struct {
 }
 
 func (e *EncoderDefault) Encode(a *Attribute) error {
-	return fmt.Errorf("can't encode default (unknown) attribute")
+	// decoded unknown attribute is written back as is
+	wire, ok := a.Value.([]byte)
+	if !ok {
+		return fmt.Errorf("can't encode default (unknown) attribute")
+	}
+	if len(wire) > 253 {
+		return errors.New("encoded Attribute is too long")
+	}
+	a.Wire = append([]byte{byte(a.Type), byte(len(wire) + 2)}, wire...)
+	return nil
 }
 func (e *EncoderDefault) Decode(a *Attribute) error {
 	a.Type = AttributeType(a.Wire[0])
@@ -110,10 +121,103 @@ func (e *EncoderAddress) Decode(a *Attribute) error {
 	return nil
 }
 
+// EncoderOctets keeps value as []byte, non zero Length requires value of exactly that size
+type EncoderOctets struct {
+	Length int
+}
+
+func (e *EncoderOctets) Encode(a *Attribute) error {
+	wire, ok := a.Value.([]byte)
+	if !ok {
+		return errors.New("octets Attribute must be []byte")
+	}
+	if len(wire) > 253 {
+		return errors.New("encoded Attribute is too long")
+	}
+	if e.Length != 0 && len(wire) != e.Length {
+		return fmt.Errorf("octets Attribute must be %d bytes long", e.Length)
+	}
+	a.Wire = append([]byte{byte(a.Type), byte(len(wire) + 2)}, wire...)
+	return nil
+}
+
+func (e *EncoderOctets) Decode(a *Attribute) error {
+	a.Type = AttributeType(a.Wire[0])
+	if e.Length != 0 && len(a.Wire[2:]) != e.Length {
+		return fmt.Errorf("octets Attribute has invalid size %d", len(a.Wire[2:]))
+	}
+	a.Value = a.Wire[2:]
+	return nil
+}
+
+type EncoderIPv6Address struct{}
+
+func (e *EncoderIPv6Address) Encode(a *Attribute) error {
+	ip, ok := a.Value.(net.IP)
+	if !ok {
+		return errors.New("ipv6 address Attribute must be net.IP")
+	}
+	if ip.To4() != nil || ip.To16() == nil {
+		return errors.New("ipv6 address Attribute must be an IPv6 net.IP")
+	}
+	a.Wire = append([]byte{byte(a.Type), net.IPv6len + 2}, ip.To16()...)
+	return nil
+}
+
+func (e *EncoderIPv6Address) Decode(a *Attribute) error {
+	a.Type = AttributeType(a.Wire[0])
+	if len(a.Wire[2:]) != net.IPv6len {
+		return errors.New("ipv6 address Attribute has invalid size")
+	}
+	a.Value = net.IP(string(a.Wire[2:]))
+	return nil
+}
+
+/*
+	rfc 3162: reserved octet, prefix length in bits and prefix octets,
+	value is *net.IPNet
+*/
+type EncoderIPv6Prefix struct{}
+
+func (e *EncoderIPv6Prefix) Encode(a *Attribute) error {
+	prefix, ok := a.Value.(*net.IPNet)
+	if !ok {
+		return errors.New("ipv6 prefix Attribute must be *net.IPNet")
+	}
+	ones, bits := prefix.Mask.Size()
+	if bits != 8*net.IPv6len || prefix.IP.To4() != nil || prefix.IP.To16() == nil {
+		return errors.New("ipv6 prefix Attribute must be an IPv6 *net.IPNet")
+	}
+	ip := prefix.IP.To16().Mask(prefix.Mask)
+	wire := append([]byte{0, byte(ones)}, ip[:(ones+7)/8]...)
+	a.Wire = append([]byte{byte(a.Type), byte(len(wire) + 2)}, wire...)
+	return nil
+}
+
+func (e *EncoderIPv6Prefix) Decode(a *Attribute) error {
+	a.Type = AttributeType(a.Wire[0])
+	wire := a.Wire[2:]
+	if len(wire) < 2 || len(wire) > 2+net.IPv6len {
+		return errors.New("ipv6 prefix Attribute has invalid size")
+	}
+	ones := int(wire[1])
+	if ones > 8*net.IPv6len || (ones+7)/8 > len(wire)-2 {
+		return fmt.Errorf("ipv6 prefix Attribute has invalid prefix length %d", ones)
+	}
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, wire[2:])
+	mask := net.CIDRMask(ones, 8*net.IPv6len)
+	a.Value = &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+	return nil
+}
+
 type EncoderUint32 struct{}
 
 func (e *EncoderUint32) Decode(a *Attribute) error {
 	a.Type = AttributeType(a.Wire[0])
+	if len(a.Wire[2:]) != 4 {
+		return errors.New("integer Attribute has invalid size")
+	}
 	a.Value = binary.BigEndian.Uint32(a.Wire[2:])
 	return nil
 }
@@ -125,7 +229,7 @@ func (e *EncoderUint32) Encode(a *Attribute) error {
 		return err
 	}
 
-	if integer, ok := a.Value.(uint32); !ok {
+	if integer, ok := uint32Value(a.Value); !ok {
 		return errors.New("integer Attribute must be uint32")
 	} else {
 		binary.BigEndian.PutUint32(wire, integer)
@@ -145,9 +249,64 @@ func (e *EncoderUint32) Encode(a *Attribute) error {
 	return nil
 }
 
+// EncoderDate keeps value as time.Time, wire is seconds since epoch. Seconds as uint32 are
+// encoded too as date attributes were integers before, decoded value is time.Time
+type EncoderDate struct{}
+
+func (e *EncoderDate) Encode(a *Attribute) error {
+	var date time.Time
+	switch v := a.Value.(type) {
+	case time.Time:
+		date = v
+	case uint32:
+		date = time.Unix(int64(v), 0)
+	default:
+		return errors.New("date Attribute must be time.Time or uint32")
+	}
+	if date.Unix() < 0 || date.Unix() > math.MaxUint32 {
+		return errors.New("date Attribute is out of range")
+	}
+	a.Wire = []byte{byte(a.Type), 6}
+	a.Wire = binary.BigEndian.AppendUint32(a.Wire, uint32(date.Unix()))
+	return nil
+}
+
+func (e *EncoderDate) Decode(a *Attribute) error {
+	a.Type = AttributeType(a.Wire[0])
+	if len(a.Wire[2:]) != 4 {
+		return errors.New("date Attribute has invalid size")
+	}
+	a.Value = time.Unix(int64(binary.BigEndian.Uint32(a.Wire[2:])), 0).UTC()
+	return nil
+}
+
+// EncoderUint64 is rfc 6929 integer64, value is uint64
+type EncoderUint64 struct{}
+
+func (e *EncoderUint64) Encode(a *Attribute) error {
+	integer, ok := a.Value.(uint64)
+	if !ok {
+		return errors.New("integer64 Attribute must be uint64")
+	}
+	a.Wire = []byte{byte(a.Type), 10}
+	a.Wire = binary.BigEndian.AppendUint64(a.Wire, integer)
+	return nil
+}
+
+func (e *EncoderUint64) Decode(a *Attribute) error {
+	a.Type = AttributeType(a.Wire[0])
+	if len(a.Wire[2:]) != 8 {
+		return errors.New("integer64 Attribute has invalid size")
+	}
+	a.Value = binary.BigEndian.Uint64(a.Wire[2:])
+	return nil
+}
+
 type VSA struct {
 	VendorType uint8
 	Value      []byte
+	// Data holds decoded Value if the vendor attribute is known to dictionary
+	Data interface{}
 }
 type EncoderVendorSpec struct{}
 
@@ -197,28 +356,123 @@ func (e *EncoderVendorSpec) Decode(a *Attribute) error {
 	}
 	a.Type = AttributeType(a.Wire[0])
 	a.Value = binary.BigEndian.Uint32([]byte{a.Wire[2], a.Wire[3], a.Wire[4], a.Wire[5]})
+	vendorId := a.Value.(uint32)
 	offset := 6
 	for len(a.Wire[offset:]) > 0 {
+		if len(a.Wire[offset:]) < 2 {
+			return fmt.Errorf("vsa must be at least 2 bytes long, but it's length is %d", len(a.Wire[offset:]))
+		}
 		vsa := new(VSA)
 		vsa.VendorType = uint8(a.Wire[offset])
 		var vsaLength int
 		vsaLength = int(a.Wire[offset+1])
+		if vsaLength < 2 || offset+vsaLength > len(a.Wire) {
+			return fmt.Errorf("invalid vsa length %d", vsaLength)
+		}
 		vsa.Value = a.Wire[offset+2 : offset+vsaLength]
+		if ai, ok := lookupVSA(vendorId, vsa.VendorType); ok {
+			// malformed value of known vsa is kept raw as of unknown one, NAS bugs
+			// in a single vsa must not fail the whole packet
+			if err := ai.Encoder.Decode(vsa); err != nil {
+				vsa.Data = nil
+			}
+		}
 		a.Pairs = append(a.Pairs, vsa)
 		offset += vsaLength
 	}
 	return nil
 }
 
-type EncoderTunnel struct{}
+/*
+	rfc 2868 tagged attribute: integer value takes 3 octets after the tag,
+	tag of string value is optional, encrypted value is kept as []byte
+*/
+type EncoderTunnel struct {
+	DataType  DataType
+	Encrypted bool
+}
 
 func (e *EncoderTunnel) Encode(a *Attribute) error {
-	//todo implement in need
+	if a.Tag > 0x1f {
+		return fmt.Errorf("invalid tag %d", a.Tag)
+	}
+	var wire []byte
+	switch {
+	case e.Encrypted:
+		raw, ok := a.Value.([]byte)
+		if !ok {
+			return errors.New("encrypted tagged Attribute must be []byte")
+		}
+		wire = append([]byte{a.Tag}, raw...)
+	case e.DataType == DataType_Integer:
+		integer, ok := uint32Value(a.Value)
+		if !ok {
+			return errors.New("integer Attribute must be uint32")
+		}
+		if integer > 0xffffff {
+			return errors.New("tagged integer Attribute must fit 3 octets")
+		}
+		wire = []byte{a.Tag, byte(integer >> 16), byte(integer >> 8), byte(integer)}
+	default:
+		switch v := a.Value.(type) {
+		case string:
+			wire = append([]byte{a.Tag}, v...)
+		case []byte:
+			wire = append([]byte{a.Tag}, v...)
+		default:
+			return errors.New("text Attribute must be string or []byte")
+		}
+	}
+	if len(wire) > 253 {
+		return errors.New("encoded Attribute is too long")
+	}
+	a.Wire = append([]byte{byte(a.Type), byte(len(wire) + 2)}, wire...)
 	return nil
 }
+
 func (e *EncoderTunnel) Decode(a *Attribute) error {
 	a.Type = AttributeType(a.Wire[0])
-	a.Tag = a.Wire[2]
-	a.Value = a.Wire[3:]
+	a.Tag = 0
+	wire := a.Wire[2:]
+	switch {
+	case e.Encrypted:
+		if len(wire) < 1 {
+			return errors.New("tagged Attribute is too short")
+		}
+		a.Tag = wire[0]
+		a.Value = wire[1:]
+	case e.DataType == DataType_Integer:
+		if len(wire) != 4 {
+			return errors.New("tagged integer Attribute has invalid size")
+		}
+		a.Tag = wire[0]
+		a.Value = uint32(wire[1])<<16 | uint32(wire[2])<<8 | uint32(wire[3])
+	default:
+		if len(wire) > 0 && wire[0] <= 0x1f {
+			a.Tag = wire[0]
+			wire = wire[1:]
+		}
+		a.Value = string(wire)
+	}
+	return nil
+}
+
+// EncoderEnum makes named values of enumerated attribute from integers decoded by Encoder
+type EncoderEnum struct {
+	Encoder EncoderInterface
+	New     func(uint32) interface{}
+}
+
+func (e *EncoderEnum) Encode(a *Attribute) error {
+	return e.Encoder.Encode(a)
+}
+
+func (e *EncoderEnum) Decode(a *Attribute) error {
+	if err := e.Encoder.Decode(a); err != nil {
+		return err
+	}
+	if integer, ok := a.Value.(uint32); ok {
+		a.Value = e.New(integer)
+	}
 	return nil
 }
@@ -217,3 +217,77 @@ func TestAttrVendorSpec_Decode(t *testing.T) {
 	}
 
 }
+
+func TestAttributeType_Name(t *testing.T) {
+	names := map[AttributeType]string{
+		Attr_FramedRoute:          "Framed-Route",
+		Attr_ErrorCause:           "Error-Cause",
+		Attr_EAPMessage:           "EAP-Message",
+		Attr_MessageAuthenticator: "Message-Authenticator",
+		Attr_NASIPv6Address:       "NAS-IPv6-Address",
+	}
+	for at, name := range names {
+		if at.Name() != name {
+			t.Errorf("Expected %s got %q", name, at.Name())
+		}
+	}
+}
+
+func TestAttrIPv6Prefix_Decode(t *testing.T) {
+	var (
+		a   *Attribute
+		err error
+	)
+	if a, err = NewAttribute(Attr_FramedIPv6Prefix); err != nil {
+		t.Fatal(err)
+	}
+	_, prefix, _ := net.ParseCIDR("2001:db8:1::/48")
+	a.Value = prefix
+	if err := a.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Wire) != 2+2+6 {
+		t.Errorf("Expected 10 bytes got %x", a.Wire)
+	}
+
+	a.Value = nil
+	if err := a.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if a.Value.(*net.IPNet).String() != "2001:db8:1::/48" {
+		t.Errorf("Expected 2001:db8:1::/48 got %v", a.Value)
+	}
+}
+
+func TestAttrRFC2869_Encode(t *testing.T) {
+	p := NewPacket(Code_AccessAccept, []byte("ctrhtn"))
+	p.AddAttribute(Attr_AcctInterimInterval, uint32(300))
+	p.AddAttribute(Attr_FramedPool, "pool-1")
+	p.AddAttribute(Attr_Prompt, Prompt_NoEcho)
+	p.AddAttribute(Attr_ARAPPassword, bytes.Repeat([]byte{1}, 16))
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := &Packet{Wire: p.Wire}
+	if err := reply.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	var interval uint32
+	if err := reply.Attr(Attr_AcctInterimInterval).ValueUint32(&interval); err != nil || interval != 300 {
+		t.Errorf("Expected 300 got %d (%v)", interval, err)
+	}
+	var pool string
+	if err := reply.Attr(Attr_FramedPool).ValueString(&pool); err != nil || pool != "pool-1" {
+		t.Errorf("Expected pool-1 got %s (%v)", pool, err)
+	}
+	if v := reply.Attr(Attr_Prompt).Value; v != Prompt_NoEcho {
+		t.Errorf("Expected %s got %v", Prompt_NoEcho, v)
+	}
+
+	arap, _ := NewAttribute(Attr_ARAPPassword)
+	arap.Value = []byte("short")
+	if err := arap.Encode(); err == nil {
+		t.Errorf("Expected: err got: %v", arap.Wire)
+	}
+}
@@ -12,8 +12,8 @@ func Exchange(packetBytes []byte, dst *net.UDPAddr, src *net.UDPAddr, retries in
 		buf  [MaxPacketLength]byte
 	)
 
-	if conn, err = net.DialUDP("udp4", src, dst); err != nil {
-		err = fmt.Errorf("net.DialUDP: %v", err)
+	if conn, err = net.DialUDP("udp", src, dst); err != nil {
+		err = fmt.Errorf("net.DialUDP: %w", err)
 		return
 	}
 
@@ -21,15 +21,15 @@ func Exchange(packetBytes []byte, dst *net.UDPAddr, src *net.UDPAddr, retries in
 	var n int
 	for i := 0; i < retries; i++ {
 		if err = conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
-			err = fmt.Errorf("conn.SetWriteDeadline: %v", err)
+			err = fmt.Errorf("conn.SetWriteDeadline: %w", err)
 			continue
 		}
 		if _, err = conn.Write(packetBytes); err != nil {
-			err = fmt.Errorf("conn.Write: %v", err)
+			err = fmt.Errorf("conn.Write: %w", err)
 			continue
 		}
 		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
-			err = fmt.Errorf("conn.SetReadDeadline: %v", err)
+			err = fmt.Errorf("conn.SetReadDeadline: %w", err)
 			continue
 		}
 
@@ -37,7 +37,7 @@ func Exchange(packetBytes []byte, dst *net.UDPAddr, src *net.UDPAddr, retries in
 			bytes = buf[:n]
 			break
 		} else {
-			err = fmt.Errorf("conn.Read: %v", err)
+			err = fmt.Errorf("conn.Read: %w", err)
 		}
 
 	}
@@ -45,3 +45,47 @@ func Exchange(packetBytes []byte, dst *net.UDPAddr, src *net.UDPAddr, retries in
 	conn.Close()
 	return
 }
+
+/*
+ExchangeCoA sends CoA-Request or Disconnect-Request to the NAS and returns checked reply,
+for NAK reply the error is *NAKError wrapping its Error-Cause:
+
+	errors.Is(err, ErrorCause_SessionContextNotFound)
+*/
+func ExchangeCoA(request *Packet, dst *net.UDPAddr, retries int, timeout time.Duration) (reply *Packet, err error) {
+	if request.Type != Code_CoARequest && request.Type != Code_DisconnectRequest {
+		return nil, fmt.Errorf("ExchangeCoA: unexpected request %s", request.Type)
+	}
+	if reply, err = ExchangePacket(request, dst, retries, timeout); err != nil {
+		return nil, fmt.Errorf("ExchangeCoA: %w", err)
+	}
+	return reply, reply.ReplyError()
+}
+
+// ExchangePacket sends encoded request and returns reply with checked Identifier and authenticators
+func ExchangePacket(request *Packet, dst *net.UDPAddr, retries int, timeout time.Duration) (reply *Packet, err error) {
+	if err = request.Encode(); err != nil {
+		return nil, err
+	}
+	var wire []byte
+	if wire, err = Exchange(request.Wire, dst, nil, retries, timeout); err != nil {
+		return nil, err
+	}
+
+	reply = &Packet{Wire: wire, RequestAuthenticator: request.Authenticator, Secret: request.Secret}
+	if err = reply.Decode(); err != nil {
+		return nil, fmt.Errorf("reply decode: %v", err)
+	}
+	if reply.Identifier != request.Identifier {
+		return nil, fmt.Errorf("reply identifier %d doesn't match request %d", reply.Identifier, request.Identifier)
+	}
+	if ok, err := reply.CheckResponseAuthenticator(request.Secret); err != nil || !ok {
+		return nil, fmt.Errorf("invalid reply authenticator (%v)", err)
+	}
+	if reply.Attr(Attr_MessageAuthenticator) != nil {
+		if ok, err := reply.CheckMessageAuthenticator(request.Secret); err != nil || !ok {
+			return nil, fmt.Errorf("invalid reply Message-Authenticator (%v)", err)
+		}
+	}
+	return reply, nil
+}
@@ -0,0 +1,138 @@
+package radius
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// Client is NAS allowed to send requests to Server
+type Client struct {
+	Name   string
+	Secret []byte
+	// NASIdentifiers are allowed NAS-Identifier values of requests, any if empty
+	NASIdentifiers []string
+}
+
+// SecretSource finds client of request source, requests of unknown sources are dropped by Server
+type SecretSource interface {
+	Lookup(addr *net.UDPAddr) (*Client, bool)
+}
+
+/*
+ClientTable is SecretSource of clients by source IP addresses and CIDR ranges,
+most specific range wins:
+
+	clients := &radius.ClientTable{}
+	clients.Add("10.0.0.0/8", &radius.Client{Name: "nas-pool", Secret: []byte("s1")})
+	clients.Add("10.1.2.3", &radius.Client{Name: "bras1", Secret: []byte("s2"), NASIdentifiers: []string{"bras1"}})
+*/
+type ClientTable struct {
+	mu      sync.RWMutex
+	entries []clientEntry
+}
+
+type clientEntry struct {
+	network *net.IPNet
+	client  *Client
+}
+
+// Add adds client of IP address or CIDR range, range of existing entry is replaced
+func (t *ClientTable) Add(cidr string, client *Client) error {
+	if client == nil || len(client.Secret) == 0 {
+		return errors.New("client must have secret")
+	}
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return fmt.Errorf("invalid client address %q", cidr)
+		}
+		if ip.To4() != nil {
+			cidr += "/32"
+		} else {
+			cidr += "/128"
+		}
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid client range %q", cidr)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, e := range t.entries {
+		if e.network.String() == network.String() {
+			t.entries[i].client = client
+			return nil
+		}
+	}
+	t.entries = append(t.entries, clientEntry{network, client})
+	return nil
+}
+
+// Lookup finds client of the most specific range containing the address
+func (t *ClientTable) Lookup(addr *net.UDPAddr) (*Client, bool) {
+	if addr == nil {
+		return nil, false
+	}
+	ip := addr.IP
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var found *Client
+	longest := -1
+	for _, e := range t.entries {
+		if ones, _ := e.network.Mask.Size(); ones > longest && e.network.Contains(ip) {
+			found, longest = e.client, ones
+		}
+	}
+	return found, found != nil
+}
+
+var (
+	errUnknownClient    = errors.New("unknown client")
+	errBadAuthenticator = errors.New("bad authenticator")
+)
+
+// authenticate finds client of request and verifies request with its secret
+func authenticate(secrets SecretSource, r *Request) error {
+	client, ok := secrets.Lookup(r.RemoteAddr)
+	if !ok {
+		return fmt.Errorf("%w %s", errUnknownClient, r.RemoteAddr)
+	}
+	r.Client = client
+	r.Secret = client.Secret
+	p := r.Packet
+	p.Secret = client.Secret
+
+	if len(client.NASIdentifiers) > 0 {
+		var nasId string
+		if a := p.Attr(Attr_NASIdentifier); a != nil {
+			nasId, _ = a.Value.(string)
+		}
+		allowed := false
+		for _, id := range client.NASIdentifiers {
+			allowed = allowed || id == nasId
+		}
+		if !allowed {
+			return fmt.Errorf("client %s: NAS-Identifier %q is not allowed", client.Name, nasId)
+		}
+	}
+
+	switch p.Type {
+	case Code_AccountingRequest, Code_CoARequest, Code_DisconnectRequest:
+		if ok, err := p.CheckAccountingRequestAuthenticator(client.Secret); err != nil || !ok {
+			return fmt.Errorf("client %s: %w: invalid Request Authenticator", client.Name, errBadAuthenticator)
+		}
+	}
+	if p.Attr(Attr_MessageAuthenticator) != nil {
+		if ok, err := p.CheckMessageAuthenticator(client.Secret); err != nil || !ok {
+			return fmt.Errorf("client %s: %w: invalid Message-Authenticator", client.Name, errBadAuthenticator)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package radius
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestClientTable_Lookup(t *testing.T) {
+	clients := &ClientTable{}
+	for cidr, name := range map[string]string{
+		"10.0.0.0/8":    "pool",
+		"10.1.2.3":      "bras1",
+		"2001:db8::/32": "v6",
+	} {
+		if err := clients.Add(cidr, &Client{Name: name, Secret: []byte(name)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := clients.Add("10.0.0.0/33", &Client{Secret: []byte("x")}); err == nil {
+		t.Error("Expected error of invalid range")
+	}
+
+	for ip, want := range map[string]string{
+		"10.1.2.3":        "bras1",
+		"::ffff:10.1.2.3": "bras1",
+		"10.1.2.4":        "pool",
+		"2001:db8:1::1":   "v6",
+		"192.0.2.1":       "",
+	} {
+		client, ok := clients.Lookup(&net.UDPAddr{IP: net.ParseIP(ip), Port: 1000})
+		if want == "" {
+			if ok {
+				t.Errorf("%s: Expected unknown client got %s", ip, client.Name)
+			}
+			continue
+		}
+		if !ok || client.Name != want {
+			t.Errorf("%s: Expected %s got %v", ip, want, client)
+		}
+	}
+}
+
+func TestServer_Secrets(t *testing.T) {
+	secret := []byte("ctrhtn")
+	clients := &ClientTable{}
+	clients.Add("127.0.0.0/8", &Client{Name: "local", Secret: secret, NASIdentifiers: []string{"nas1"}})
+	s := &Server{
+		Secrets: clients,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			if r.Client == nil || r.Client.Name != "local" || string(r.Secret) != string(secret) {
+				t.Errorf("Expected local client got %+v", r.Client)
+			}
+			// secret of reply comes from the client
+			w.Write(&Packet{Type: Code_AccountingResponse})
+		}),
+	}
+	addr, _ := startServer(t, s)
+
+	for _, c := range []struct {
+		secret string
+		nasId  string
+		ok     bool
+	}{
+		{"ctrhtn", "nas1", true},
+		{"wrong", "nas1", false},
+		{"ctrhtn", "nas2", false},
+	} {
+		request := NewPacket(Code_AccountingRequest, []byte(c.secret))
+		request.AddAttribute(Attr_NASIdentifier, c.nasId)
+		_, err := ExchangePacket(request, addr, 1, 200*time.Millisecond)
+		if c.ok && err != nil {
+			t.Errorf("%s %s: Expected reply got %v", c.secret, c.nasId, err)
+		}
+		if !c.ok && err == nil {
+			t.Errorf("%s %s: Expected request to be dropped", c.secret, c.nasId)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package radius
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestExchange_IPv6(t *testing.T) {
+	conn, err := net.ListenUDP("udp6", &net.UDPAddr{IP: net.IPv6loopback})
+	if err != nil {
+		t.Skipf("udp6 is not available: %v", err)
+	}
+	defer conn.Close()
+	go func() {
+		buf := make([]byte, MaxPacketLength)
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err == nil {
+			conn.WriteToUDP(buf[:n], addr)
+		}
+	}()
+	request := []byte{byte(Code_AccessRequest), 1, 0, 20}
+	request = append(request, make([]byte, 16)...)
+	reply, err := Exchange(request, conn.LocalAddr().(*net.UDPAddr), nil, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reply) != len(request) {
+		t.Errorf("Expected %d bytes got %d", len(request), len(reply))
+	}
+}
@@ -0,0 +1,327 @@
+/*
+radclient sends RADIUS packets with attributes in radclient text syntax and
+prints decoded replies.
+
+Usage:
+
+	radclient [-f file] [-p parallel] [-r retries] [-t timeout] [-q] [-x] server[:port] command secret
+
+Command is auth, acct, status, coa, disconnect or packet code number. Packets
+are read from files given with -f, which may be repeated, or from stdin, every
+blank line separated block of a file is a packet:
+
+	User-Name = "bob"
+	User-Password = "hunter2"
+	NAS-Identifier = "nas1"
+
+User-Password is encrypted and CHAP-Password is hashed from the plain text
+password, Status-Server and EAP-Message get Message-Authenticator and given
+Message-Authenticator is signed. Exit status
+is 1 if any packet got no valid reply.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+type files []string
+
+func (f *files) String() string { return strings.Join(*f, ",") }
+
+func (f *files) Set(name string) error {
+	*f = append(*f, name)
+	return nil
+}
+
+type config struct {
+	server   *net.UDPAddr
+	code     radius.PacketType
+	secret   []byte
+	parallel int
+	retries  int
+	timeout  time.Duration
+	quiet    bool
+	dissect  bool
+}
+
+func main() {
+	var names files
+	var c config
+	flag.Var(&names, "f", "file of packets, - is stdin, may be repeated")
+	flag.IntVar(&c.parallel, "p", 1, "packets sent in parallel")
+	flag.IntVar(&c.retries, "r", 3, "attempts of every packet")
+	flag.DurationVar(&c.timeout, "t", 3*time.Second, "timeout of every attempt")
+	flag.BoolVar(&c.quiet, "q", false, "don't print packets")
+	flag.BoolVar(&c.dissect, "x", false, "print annotated hex of packets")
+	flag.Parse()
+
+	if err := c.parseArgs(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "radclient: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if len(names) == 0 {
+		names = files{"-"}
+	}
+	packets, err := readPackets(names, os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "radclient: %v\n", err)
+		os.Exit(2)
+	}
+	if failed := c.send(packets, os.Stdout, os.Stderr); failed > 0 {
+		os.Exit(1)
+	}
+}
+
+var commands = map[string]struct {
+	code radius.PacketType
+	port int
+}{
+	"auth":       {radius.Code_AccessRequest, 1812},
+	"acct":       {radius.Code_AccountingRequest, 1813},
+	"status":     {radius.Code_StatusServer, 1812},
+	"coa":        {radius.Code_CoARequest, 3799},
+	"disconnect": {radius.Code_DisconnectRequest, 3799},
+}
+
+func (c *config) parseArgs(args []string) error {
+	if len(args) != 3 {
+		return errors.New("expected server, command and secret")
+	}
+	port := 1812
+	if command, ok := commands[args[1]]; ok {
+		c.code, port = command.code, command.port
+	} else if code, err := strconv.ParseUint(args[1], 10, 8); err == nil {
+		c.code = radius.PacketType(code)
+	} else {
+		return fmt.Errorf("unknown command %q", args[1])
+	}
+
+	host := args[0]
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), strconv.Itoa(port))
+	}
+	var err error
+	if c.server, err = net.ResolveUDPAddr("udp", host); err != nil {
+		return err
+	}
+	c.secret = []byte(args[2])
+	if c.parallel < 1 {
+		c.parallel = 1
+	}
+	return nil
+}
+
+// packetText is attributes of a packet and where they were read
+type packetText struct {
+	source string
+	attrs  []*radius.Attribute
+}
+
+// readPackets reads blank line separated packets of files, - is stdin
+func readPackets(names []string, stdin io.Reader) ([]packetText, error) {
+	var packets []packetText
+	for _, name := range names {
+		var b []byte
+		var err error
+		if name == "-" {
+			b, err = io.ReadAll(stdin)
+		} else {
+			b, err = os.ReadFile(name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, text := range splitPackets(string(b)) {
+			attrs, err := radius.ParseAttributes(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s: packet %d: %v", name, i+1, err)
+			}
+			packets = append(packets, packetText{source: fmt.Sprintf("%s#%d", name, i+1), attrs: attrs})
+		}
+	}
+	if len(packets) == 0 {
+		// status and empty disconnect need no attributes
+		packets = append(packets, packetText{source: "-#1"})
+	}
+	return packets, nil
+}
+
+func splitPackets(text string) []string {
+	var packets []string
+	var current []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				packets = append(packets, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		packets = append(packets, strings.Join(current, "\n"))
+	}
+	return packets
+}
+
+// send sends packets with c.parallel workers and returns number of packets without valid reply
+func (c *config) send(packets []packetText, stdout, stderr io.Writer) (failed int) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan packetText)
+	for i := 0; i < c.parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pt := range jobs {
+				var out bytes.Buffer
+				err := c.exchange(pt, &out)
+				mu.Lock()
+				stdout.Write(out.Bytes())
+				if err != nil {
+					failed++
+					fmt.Fprintf(stderr, "radclient: %s: %v\n", pt.source, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, pt := range packets {
+		jobs <- pt
+	}
+	close(jobs)
+	wg.Wait()
+	return failed
+}
+
+func (c *config) exchange(pt packetText, out io.Writer) error {
+	request, err := c.newRequest(pt.attrs)
+	if err != nil {
+		return err
+	}
+	if err := request.Encode(); err != nil {
+		return err
+	}
+	if !c.quiet {
+		fmt.Fprintf(out, "Sent %s Id %d to %s length %d\n", name(request.Type), request.Identifier, c.server, len(request.Wire))
+		fmt.Fprint(out, indent(radius.FormatAttributes(pt.attrs)))
+		if c.dissect {
+			fmt.Fprint(out, indent(radius.Dissect(request.Wire)))
+		}
+	}
+
+	reply, err := radius.ExchangePacket(request, c.server, c.retries, c.timeout)
+	if err != nil {
+		return err
+	}
+	if !c.quiet {
+		fmt.Fprintf(out, "Received %s Id %d from %s length %d\n", name(reply.Type), reply.Identifier, c.server, len(reply.Wire))
+		fmt.Fprint(out, indent(radius.FormatAttributes(reply.Attributes)))
+		if c.dissect {
+			fmt.Fprint(out, indent(radius.Dissect(reply.Wire)))
+		}
+	}
+	return nil
+}
+
+// newRequest makes packet of the command, passwords of Access-Request are given in plain text
+func (c *config) newRequest(attrs []*radius.Attribute) (*radius.Packet, error) {
+	request := radius.NewPacket(c.code, c.secret)
+	if request == nil {
+		return nil, errors.New("can't make packet identifier")
+	}
+	var password string
+	var chap, hasPassword, hasMessageAuthenticator, hasEAP bool
+	for _, a := range attrs {
+		switch a.Type {
+		case radius.Attr_UserPassword:
+			password, hasPassword = textOf(a.Value), true
+			continue
+		case radius.Attr_CHAPPassword:
+			password, chap = textOf(a.Value), true
+			continue
+		case radius.Attr_MessageAuthenticator:
+			hasMessageAuthenticator = true
+		case radius.Attr_EAPMessage:
+			hasEAP = true
+		}
+		request.AddAttr(a)
+	}
+	if hasPassword {
+		if err := request.SetUserPassword(password); err != nil {
+			return nil, err
+		}
+	}
+	if chap {
+		if err := setCHAPPassword(request, password); err != nil {
+			return nil, err
+		}
+	}
+	if hasMessageAuthenticator || c.code == radius.Code_StatusServer || hasEAP {
+		request.SignMessageAuthenticator = true
+	}
+	return request, nil
+}
+
+// setCHAPPassword adds CHAP-Password of rfc 1994 with Request Authenticator as challenge
+// unless packet has CHAP-Challenge
+func setCHAPPassword(p *radius.Packet, password string) error {
+	if p.Authenticator == [16]byte{} {
+		if err := p.MakeAccessRequestAuthenticator(); err != nil {
+			return err
+		}
+	}
+	// the authenticator is the challenge, Encode must not replace it
+	p.KeepAuthenticator = true
+	challenge := p.Authenticator[:]
+	if a := p.Attr(radius.Attr_CHAPChallenge); a != nil {
+		challenge = []byte(textOf(a.Value))
+	}
+	id := p.Identifier
+	hash := md5.New()
+	hash.Write([]byte{id})
+	hash.Write([]byte(password))
+	hash.Write(challenge)
+	return p.AddAttribute(radius.Attr_CHAPPassword, string(append([]byte{id}, hash.Sum(nil)...)))
+}
+
+func textOf(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// name is packet code name without number
+func name(code radius.PacketType) string {
+	if s := code.String(); s != "" {
+		return strings.TrimSuffix(s, fmt.Sprintf("(%d)", code))
+	}
+	return fmt.Sprintf("code %d", code)
+}
+
+func indent(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "\t" + strings.ReplaceAll(strings.TrimSuffix(text, "\n"), "\n", "\n\t") + "\n"
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+const testPackets = `
+User-Name = "bob"
+User-Password = "hunter2"
+NAS-Identifier = "nas1"
+
+# second packet
+User-Name = "alice"
+User-Password = "wrong"
+NAS-Identifier = "nas1"
+`
+
+// serve accepts Access-Request with password hunter2 and rejects others
+func serve(t *testing.T, conn *net.UDPConn, secret []byte) {
+	buff := make([]byte, radius.MaxPacketLength)
+	for {
+		n, addr, err := conn.ReadFromUDP(buff)
+		if err != nil {
+			return
+		}
+		request := &radius.Packet{Wire: append([]byte(nil), buff[:n]...), Secret: secret}
+		if err := request.Decode(); err != nil {
+			t.Error(err)
+			continue
+		}
+		code := radius.Code_AccessReject
+		if password, _ := request.UserPassword(); password == "hunter2" {
+			code = radius.Code_AccessAccept
+		}
+		reply := &radius.Packet{
+			Type:                 code,
+			Identifier:           request.Identifier,
+			RequestAuthenticator: request.Authenticator,
+			Secret:               secret,
+		}
+		reply.AddAttribute(radius.Attr_ReplyMessage, "hello "+request.Attr(radius.Attr_UserName).Value.(string))
+		if err := reply.Encode(); err != nil {
+			t.Error(err)
+			continue
+		}
+		conn.WriteToUDP(reply.Wire, addr)
+	}
+}
+
+func TestSend(t *testing.T) {
+	secret := []byte("ctrhtn")
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	go serve(t, conn, secret)
+
+	var c config
+	if err := c.parseArgs([]string{conn.LocalAddr().String(), "auth", string(secret)}); err != nil {
+		t.Fatal(err)
+	}
+	c.parallel, c.retries, c.timeout = 2, 1, time.Second
+	packets, err := readPackets([]string{"-"}, strings.NewReader(testPackets))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 2 {
+		t.Fatalf("Expected 2 packets got %d", len(packets))
+	}
+
+	var stdout, stderr bytes.Buffer
+	if failed := c.send(packets, &stdout, &stderr); failed != 0 {
+		t.Fatalf("Expected no failures got %d: %s", failed, stderr.String())
+	}
+	out := stdout.String()
+	for _, s := range []string{
+		"Received Access-Accept Id",
+		"\tReply-Message = \"hello bob\"\n",
+		"Received Access-Reject Id",
+		"\tReply-Message = \"hello alice\"\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %q in\n%s", s, out)
+		}
+	}
+
+	// reply authenticator fails with wrong secret
+	c.secret = []byte("wrong")
+	c.timeout = 100 * time.Millisecond
+	stdout.Reset()
+	if failed := c.send(packets[:1], &stdout, &stderr); failed != 1 {
+		t.Errorf("Expected failed packet with wrong secret got %d", failed)
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	var c config
+	if err := c.parseArgs([]string{"127.0.0.1", "coa", "s"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.code != radius.Code_CoARequest || c.server.Port != 3799 {
+		t.Errorf("Expected CoA-Request to port 3799 got %s %s", c.code, c.server)
+	}
+	if err := c.parseArgs([]string{"[::1]:1645", "12", "s"}); err != nil {
+		t.Fatal(err)
+	}
+	if c.code != radius.Code_StatusServer || c.server.String() != "[::1]:1645" {
+		t.Errorf("Expected Status-Server to [::1]:1645 got %s %s", c.code, c.server)
+	}
+	if err := c.parseArgs([]string{"127.0.0.1", "bogus", "s"}); err == nil {
+		t.Error("Expected error of unknown command")
+	}
+}
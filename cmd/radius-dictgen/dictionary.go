@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// dataTypes maps dictionary data types to DataType constants of the radius package
+var dataTypes = map[string]string{
+	"string":     "DataType_String",
+	"octets":     "DataType_Octets",
+	"ipaddr":     "DataType_IPAddr",
+	"integer":    "DataType_Integer",
+	"integer64":  "DataType_Integer64",
+	"date":       "DataType_Date",
+	"vsa":        "DataType_VSA",
+	"ipv6addr":   "DataType_IPv6Addr",
+	"ipv6prefix": "DataType_IPv6Prefix",
+	"ifid":       "DataType_IfId",
+}
+
+type Vendor struct {
+	Name string
+	Id   uint32
+}
+
+type Value struct {
+	Attribute string
+	Name      string
+	Number    uint64
+	// attr is resolved by name after all dictionaries are read
+	attr *Attribute
+}
+
+type Attribute struct {
+	Name     string
+	Number   uint64
+	DataType string
+	Tagged   bool
+	Encrypt  int
+	// Length is the fixed length of octets[N] value
+	Length int
+	// Vendor is nil for standard attributes
+	Vendor *Vendor
+	// enum is set if any dictionary has values of the attribute
+	enum bool
+}
+
+// Dictionary is the content of one dictionary file
+type Dictionary struct {
+	Name       string
+	Vendors    []*Vendor
+	Attributes []*Attribute
+	Values     []*Value
+}
+
+/*
+Parse reads dictionary in FreeRADIUS format.
+Supported keywords are ATTRIBUTE, VALUE, VENDOR, BEGIN-VENDOR and END-VENDOR,
+vendors must be declared before BEGIN-VENDOR in the same file
+*/
+func Parse(r io.Reader, name string) (*Dictionary, error) {
+	d := &Dictionary{Name: name}
+	var vendor *Vendor
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var err error
+		switch fields[0] {
+		case "ATTRIBUTE":
+			var a *Attribute
+			if a, err = parseAttribute(fields); err == nil {
+				a.Vendor = vendor
+				d.Attributes = append(d.Attributes, a)
+			}
+		case "VALUE":
+			var v *Value
+			if v, err = parseValue(fields); err == nil {
+				d.Values = append(d.Values, v)
+			}
+		case "VENDOR":
+			var v *Vendor
+			if v, err = parseVendor(fields); err == nil {
+				d.Vendors = append(d.Vendors, v)
+			}
+		case "BEGIN-VENDOR":
+			if len(fields) != 2 {
+				err = fmt.Errorf("BEGIN-VENDOR expects vendor name")
+			} else if vendor != nil {
+				err = fmt.Errorf("BEGIN-VENDOR %s inside of %s", fields[1], vendor.Name)
+			} else if vendor = d.vendor(fields[1]); vendor == nil {
+				err = fmt.Errorf("unknown vendor %s", fields[1])
+			}
+		case "END-VENDOR":
+			if len(fields) != 2 || vendor == nil || vendor.Name != fields[1] {
+				err = fmt.Errorf("unexpected END-VENDOR")
+			}
+			vendor = nil
+		default:
+			err = fmt.Errorf("unsupported keyword %s", fields[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", name, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if vendor != nil {
+		return nil, fmt.Errorf("%s: missing END-VENDOR %s", name, vendor.Name)
+	}
+	return d, nil
+}
+
+func (d *Dictionary) vendor(name string) *Vendor {
+	for _, v := range d.Vendors {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+func parseAttribute(fields []string) (*Attribute, error) {
+	if len(fields) != 4 && len(fields) != 5 {
+		return nil, fmt.Errorf("ATTRIBUTE expects name, number, type and optional flags")
+	}
+	a := &Attribute{Name: fields[1], DataType: fields[3]}
+	var err error
+	if a.Number, err = strconv.ParseUint(fields[2], 0, 8); err != nil {
+		return nil, fmt.Errorf("invalid number of attribute %s: %v", a.Name, err)
+	}
+	if strings.HasPrefix(a.DataType, "octets[") && strings.HasSuffix(a.DataType, "]") {
+		length := strings.TrimSuffix(strings.TrimPrefix(a.DataType, "octets["), "]")
+		if a.Length, err = strconv.Atoi(length); err != nil || a.Length < 1 || a.Length > 253 {
+			return nil, fmt.Errorf("invalid length %s of attribute %s", length, a.Name)
+		}
+		a.DataType = "octets"
+	}
+	if _, ok := dataTypes[a.DataType]; !ok {
+		return nil, fmt.Errorf("unsupported type %s of attribute %s", a.DataType, a.Name)
+	}
+	if len(fields) == 5 {
+		for _, flag := range strings.Split(fields[4], ",") {
+			switch {
+			case flag == "has_tag":
+				a.Tagged = true
+			case strings.HasPrefix(flag, "encrypt="):
+				if a.Encrypt, err = strconv.Atoi(strings.TrimPrefix(flag, "encrypt=")); err != nil {
+					return nil, fmt.Errorf("invalid flag %s of attribute %s", flag, a.Name)
+				}
+			default:
+				return nil, fmt.Errorf("unsupported flag %s of attribute %s", flag, a.Name)
+			}
+		}
+	}
+	return a, nil
+}
+
+func parseValue(fields []string) (*Value, error) {
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("VALUE expects attribute, name and number")
+	}
+	v := &Value{Attribute: fields[1], Name: fields[2]}
+	var err error
+	if v.Number, err = strconv.ParseUint(fields[3], 0, 32); err != nil {
+		return nil, fmt.Errorf("invalid number of value %s: %v", v.Name, err)
+	}
+	return v, nil
+}
+
+func parseVendor(fields []string) (*Vendor, error) {
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("VENDOR expects name and id")
+	}
+	id, err := strconv.ParseUint(fields[2], 0, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id of vendor %s: %v", fields[1], err)
+	}
+	return &Vendor{Name: fields[1], Id: uint32(id)}, nil
+}
+
+// identifier makes exported Go identifier part from dictionary name: Framed-IP-Address -> FramedIPAddress
+func identifier(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const testDictionary = `
+# test dictionary
+ATTRIBUTE	Framed-IP-Address	8	ipaddr
+ATTRIBUTE	Tunnel-Password		69	string	has_tag,encrypt=2
+ATTRIBUTE	Acct-Status-Type	40	integer
+ATTRIBUTE	ARAP-Password		70	octets[16]
+VALUE	Acct-Status-Type	Interim-Update	3
+
+VENDOR		Cisco		9
+BEGIN-VENDOR	Cisco
+ATTRIBUTE	Cisco-AVPair		1	string
+END-VENDOR	Cisco
+`
+
+func TestParse(t *testing.T) {
+	d, err := Parse(strings.NewReader(testDictionary), "dictionary.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Attributes) != 5 || len(d.Values) != 1 || len(d.Vendors) != 1 {
+		t.Fatalf("Expected 5 attributes, 1 value and 1 vendor got %d, %d, %d", len(d.Attributes), len(d.Values), len(d.Vendors))
+	}
+	if a := d.Attributes[1]; !a.Tagged || a.Encrypt != 2 {
+		t.Errorf("Expected tagged attribute with encrypt=2 got %+v", a)
+	}
+	if a := d.Attributes[3]; a.DataType != "octets" || a.Length != 16 {
+		t.Errorf("Expected octets of 16 bytes got %+v", a)
+	}
+	if a := d.Attributes[4]; a.Vendor == nil || a.Vendor.Id != 9 {
+		t.Errorf("Expected attribute of vendor 9 got %+v", a)
+	}
+
+	wrong := []string{
+		"ATTRIBUTE	User-Name	1	abinary",
+		"ATTRIBUTE	User-Name	256	string",
+		"ATTRIBUTE	User-Name	1	string	array",
+		"ATTRIBUTE	ARAP-Password	70	octets[0]",
+		"BEGIN-VENDOR	Cisco",
+		"$INCLUDE	dictionary.rfc2865",
+	}
+	for _, s := range wrong {
+		if _, err := Parse(strings.NewReader(s), "dictionary.test"); err == nil {
+			t.Errorf("Expected: err for %q", s)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	first, _ := Parse(strings.NewReader("ATTRIBUTE	Error-Cause	101	integer"), "dictionary.first")
+	second, _ := Parse(strings.NewReader("ATTRIBUTE	Error-Cause-2	101	integer"), "dictionary.second")
+	if err := check([]*Dictionary{first, second}); err == nil {
+		t.Error("Expected: err for duplicated attribute number")
+	}
+
+	values, _ := Parse(strings.NewReader("VALUE	Service-Type	Login-User	1"), "dictionary.values")
+	if err := check([]*Dictionary{first, values}); err == nil {
+		t.Error("Expected: err for value of unknown attribute")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	d, err := Parse(strings.NewReader(testDictionary), "dictionary.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check([]*Dictionary{d}); err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate("radius", d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"// Code generated by radius-dictgen from dictionary.test. DO NOT EDIT.",
+		"Attr_FramedIPAddress AttributeType = 8 // Framed-IP-Address",
+		"type AcctStatusType uint32",
+		"AcctStatusType_InterimUpdate AcctStatusType = 3 // Interim-Update",
+		"registerEnum(Attr_AcctStatusType, func(v uint32) interface{} { return AcctStatusType(v) })",
+		`registerValue(Attr_AcctStatusType, "Interim-Update", uint32(AcctStatusType_InterimUpdate))`,
+		"Vendor_Cisco uint32 = 9 // Cisco",
+		"VSA_CiscoAVPair uint8 = 1 // Cisco-AVPair",
+		`registerAttribute(Attr_TunnelPassword, "Tunnel-Password", DataType_String, attrFlags{Tagged: true, Encrypt: 2})`,
+		`registerAttribute(Attr_ARAPPassword, "ARAP-Password", DataType_Octets, attrFlags{Length: 16})`,
+		`registerVSAType(Vendor_Cisco, VSA_CiscoAVPair, "Cisco-AVPair", DataType_String)`,
+	}
+	//alignment of gofmt doesn't matter
+	normalized := []byte(strings.Join(strings.Fields(string(src)), " "))
+	for _, s := range expected {
+		if !bytes.Contains(normalized, []byte(s)) {
+			t.Errorf("Expected %q in:\n%s", s, src)
+		}
+	}
+}
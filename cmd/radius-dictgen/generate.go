@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+func attrConst(a *Attribute) string {
+	if a.Vendor != nil {
+		return "VSA_" + identifier(a.Name)
+	}
+	return "Attr_" + identifier(a.Name)
+}
+
+func vendorConst(v *Vendor) string {
+	return "Vendor_" + identifier(v.Name)
+}
+
+// enumType is the name of Go type of attribute values
+func enumType(a *Attribute) string {
+	return identifier(a.Name)
+}
+
+func valueConst(v *Value) string {
+	return enumType(v.attr) + "_" + identifier(v.Name)
+}
+
+func attrFlags(a *Attribute) string {
+	var flags []string
+	if a.Tagged {
+		flags = append(flags, "Tagged: true")
+	}
+	if a.Encrypt != 0 {
+		flags = append(flags, fmt.Sprintf("Encrypt: %d", a.Encrypt))
+	}
+	if a.Length != 0 {
+		flags = append(flags, fmt.Sprintf("Length: %d", a.Length))
+	}
+	return "attrFlags{" + strings.Join(flags, ", ") + "}"
+}
+
+// generate makes gofmt-ed source of constants and registry entries of the dictionary
+func generate(pkg string, d *Dictionary) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by radius-dictgen from %s. DO NOT EDIT.\n\n", d.Name)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+
+	if len(d.Vendors) > 0 {
+		b.WriteString("const (\n")
+		for _, v := range d.Vendors {
+			fmt.Fprintf(&b, "%s uint32 = %d // %s\n", vendorConst(v), v.Id, v.Name)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	if len(d.Attributes) > 0 {
+		b.WriteString("const (\n")
+		for _, a := range d.Attributes {
+			typ := "AttributeType"
+			if a.Vendor != nil {
+				typ = "uint8"
+			}
+			fmt.Fprintf(&b, "%s %s = %d // %s\n", attrConst(a), typ, a.Number, a.Name)
+		}
+		b.WriteString(")\n\n")
+	}
+
+	for _, a := range d.Attributes {
+		if !a.enum {
+			continue
+		}
+		typ := enumType(a)
+		fmt.Fprintf(&b, "// %s is the value of %s\n", typ, a.Name)
+		fmt.Fprintf(&b, "type %s uint32\n\n", typ)
+		fmt.Fprintf(&b, "func (v %s) Uint32() uint32 { return uint32(v) }\n\n", typ)
+		if a.Vendor != nil {
+			fmt.Fprintf(&b, "func (v %s) String() string { return vsaValueString(%s, %s, uint32(v)) }\n\n",
+				typ, vendorConst(a.Vendor), attrConst(a))
+		} else {
+			fmt.Fprintf(&b, "func (v %s) String() string { return attrValueString(%s, uint32(v)) }\n\n",
+				typ, attrConst(a))
+		}
+	}
+
+	//values of every attribute are in their own block
+	for i, v := range d.Values {
+		if i == 0 || d.Values[i-1].attr != v.attr {
+			b.WriteString("const (\n")
+		}
+		fmt.Fprintf(&b, "%s %s = %d // %s\n", valueConst(v), enumType(v.attr), v.Number, v.Name)
+		if i == len(d.Values)-1 || d.Values[i+1].attr != v.attr {
+			b.WriteString(")\n\n")
+		}
+	}
+
+	if len(d.Vendors) > 0 || len(d.Attributes) > 0 || len(d.Values) > 0 {
+		b.WriteString("func init() {\n")
+		for _, v := range d.Vendors {
+			fmt.Fprintf(&b, "registerVendor(%s, %q)\n", vendorConst(v), v.Name)
+		}
+		for _, a := range d.Attributes {
+			if a.Vendor != nil {
+				if a.Tagged || a.Encrypt != 0 || a.Length != 0 {
+					return nil, fmt.Errorf("flags of vendor attribute %s are not supported", a.Name)
+				}
+				fmt.Fprintf(&b, "registerVSAType(%s, %s, %q, %s)\n",
+					vendorConst(a.Vendor), attrConst(a), a.Name, dataTypes[a.DataType])
+				if a.enum {
+					fmt.Fprintf(&b, "registerVSAEnum(%s, %s, func(v uint32) interface{} { return %s(v) })\n",
+						vendorConst(a.Vendor), attrConst(a), enumType(a))
+				}
+				continue
+			}
+			fmt.Fprintf(&b, "registerAttribute(%s, %q, %s, %s)\n",
+				attrConst(a), a.Name, dataTypes[a.DataType], attrFlags(a))
+			if a.enum {
+				fmt.Fprintf(&b, "registerEnum(%s, func(v uint32) interface{} { return %s(v) })\n",
+					attrConst(a), enumType(a))
+			}
+		}
+		for _, v := range d.Values {
+			if v.attr.Vendor != nil {
+				fmt.Fprintf(&b, "registerVSAValue(%s, %s, %q, uint32(%s))\n",
+					vendorConst(v.attr.Vendor), attrConst(v.attr), v.Name, valueConst(v))
+				continue
+			}
+			fmt.Fprintf(&b, "registerValue(%s, %q, uint32(%s))\n", attrConst(v.attr), v.Name, valueConst(v))
+		}
+		b.WriteString("}\n")
+	}
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,146 @@
+/*
+radius-dictgen generates attribute constants, value enumerations and registry
+entries of the radius package from dictionaries in FreeRADIUS format.
+
+Usage:
+
+	radius-dictgen [-o dir] [-package name] dictionary|dir ...
+
+Every dictionary.NAME file produces NAME_gen.go in the output directory,
+directories are expanded to the dictionary.* files they contain.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	out := flag.String("o", ".", "output directory")
+	pkg := flag.String("package", "radius", "package name of generated files")
+	flag.Parse()
+
+	if err := run(*out, *pkg, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "radius-dictgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, pkg string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no dictionaries given")
+	}
+	files, err := dictionaryFiles(args)
+	if err != nil {
+		return err
+	}
+
+	var dicts []*Dictionary
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		d, err := Parse(f, filepath.Base(name))
+		f.Close()
+		if err != nil {
+			return err
+		}
+		dicts = append(dicts, d)
+	}
+
+	if err := check(dicts); err != nil {
+		return err
+	}
+
+	for _, d := range dicts {
+		src, err := generate(pkg, d)
+		if err != nil {
+			return fmt.Errorf("%s: %v", d.Name, err)
+		}
+		name := strings.TrimPrefix(d.Name, "dictionary.") + "_gen.go"
+		if err := os.WriteFile(filepath.Join(out, name), src, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dictionaryFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		fi, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "dictionary.*"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// check finds duplicated attributes and values of unknown attributes across all dictionaries
+// and resolves attributes of values
+func check(dicts []*Dictionary) error {
+	type key struct {
+		vendor uint32
+		number uint64
+	}
+	byNumber := make(map[key]*Attribute)
+	byName := make(map[string]*Attribute)
+	vendors := make(map[uint32]string)
+
+	for _, d := range dicts {
+		for _, v := range d.Vendors {
+			if name, ok := vendors[v.Id]; ok {
+				return fmt.Errorf("%s: vendor %d is already defined as %s", d.Name, v.Id, name)
+			}
+			vendors[v.Id] = v.Name
+		}
+		for _, a := range d.Attributes {
+			k := key{number: a.Number}
+			if a.Vendor != nil {
+				k.vendor = a.Vendor.Id
+			}
+			if dup, ok := byNumber[k]; ok {
+				return fmt.Errorf("%s: attribute %s has the same number as %s", d.Name, a.Name, dup.Name)
+			}
+			if _, ok := byName[a.Name]; ok {
+				return fmt.Errorf("%s: attribute %s is already defined", d.Name, a.Name)
+			}
+			byNumber[k] = a
+			byName[a.Name] = a
+		}
+	}
+
+	for _, d := range dicts {
+		for _, v := range d.Values {
+			a, ok := byName[v.Attribute]
+			if !ok {
+				return fmt.Errorf("%s: value %s of unknown attribute %s", d.Name, v.Name, v.Attribute)
+			}
+			if a.DataType != "integer" {
+				return fmt.Errorf("%s: value %s of not integer attribute %s", d.Name, v.Name, v.Attribute)
+			}
+			if name := enumType(a); name == "" || name[0] >= '0' && name[0] <= '9' {
+				return fmt.Errorf("%s: can't make enum type name of attribute %s", d.Name, a.Name)
+			}
+			v.attr = a
+			a.enum = true
+		}
+	}
+	return nil
+}
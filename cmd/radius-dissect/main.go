@@ -0,0 +1,56 @@
+/*
+radius-dissect prints annotated fields of RADIUS packets read as hex from stdin.
+
+Usage:
+
+	radius-dissect [-split] < packet.hex
+
+Hex may be spaced, colon separated or tcpdump -x output of the UDP payload.
+With -split every blank line separated block is a packet.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+func main() {
+	split := flag.Bool("split", false, "dissect blank line separated packets")
+	flag.Parse()
+
+	if err := run(os.Stdin, os.Stdout, *split); err != nil {
+		fmt.Fprintf(os.Stderr, "radius-dissect: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(in io.Reader, out io.Writer, split bool) error {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	dumps := []string{string(b)}
+	if split {
+		dumps = strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n\n")
+	}
+	for i, dump := range dumps {
+		if strings.TrimSpace(dump) == "" {
+			continue
+		}
+		wire, err := radius.ParseHex(dump)
+		if err != nil {
+			return fmt.Errorf("packet %d: %v", i+1, err)
+		}
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprint(out, radius.Dissect(wire))
+	}
+	return nil
+}
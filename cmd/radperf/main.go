@@ -0,0 +1,356 @@
+/*
+radperf sends authentication and accounting load to a RADIUS server and
+reports latency percentiles, timeouts, rejects and throughput.
+
+Usage:
+
+	radperf [-auth file] [-acct file] [-n total] [-d duration] [-c concurrency] [-rate rps] [-local] server[:port] secret
+
+Templates are attributes in radclient text syntax with text/template actions,
+requests of both templates are interleaved, built-in ones are used if none is
+given. Template data are N, the sequence
+number of request from 0, and Worker, functions are printf, mod and hex:
+
+	User-Name = "user{{mod .N 1000}}"
+	User-Password = "hunter2"
+	Acct-Session-Id = "{{hex .N}}"
+
+Authentication goes to the server port, 1812 if not given, accounting to the
+port after it. With -local an in-process radius.Server answering everything is
+started on the server address, to size Server.ListenAndServe itself.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+const defaultAuthTemplate = `User-Name = "user{{mod .N 1000}}"
+User-Password = "password"
+NAS-Identifier = "radperf"
+`
+
+const defaultAcctTemplate = `User-Name = "user{{mod .N 1000}}"
+Acct-Status-Type = Start
+Acct-Session-Id = "{{hex .N}}"
+NAS-Identifier = "radperf"
+`
+
+type config struct {
+	auth, acct  *net.UDPAddr
+	secret      []byte
+	total       int
+	duration    time.Duration
+	concurrency int
+	rate        float64
+	retries     int
+	timeout     time.Duration
+	templates   []*requestTemplate
+}
+
+func main() {
+	var c config
+	authFile := flag.String("auth", "", "template of Access-Request")
+	acctFile := flag.String("acct", "", "template of Accounting-Request")
+	flag.IntVar(&c.total, "n", 0, "total requests, unlimited if 0")
+	flag.DurationVar(&c.duration, "d", 10*time.Second, "duration of test if -n is 0")
+	flag.IntVar(&c.concurrency, "c", 10, "requests in flight")
+	flag.Float64Var(&c.rate, "rate", 0, "requests per second, as fast as possible if 0")
+	flag.IntVar(&c.retries, "r", 1, "attempts of every request")
+	flag.DurationVar(&c.timeout, "t", time.Second, "timeout of every attempt")
+	local := flag.Bool("local", false, "start local server on the server address")
+	flag.Parse()
+
+	if err := c.parseArgs(flag.Args(), *authFile, *acctFile); err != nil {
+		fmt.Fprintf(os.Stderr, "radperf: %v\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *local {
+		if err := startLocal(c.auth, c.acct, c.secret); err != nil {
+			fmt.Fprintf(os.Stderr, "radperf: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	c.run().print(os.Stdout)
+}
+
+func (c *config) parseArgs(args []string, authFile, acctFile string) error {
+	if len(args) != 2 {
+		return errors.New("expected server and secret")
+	}
+	host := args[0]
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "1812")
+	}
+	var err error
+	if c.auth, err = net.ResolveUDPAddr("udp", host); err != nil {
+		return err
+	}
+	c.acct = &net.UDPAddr{IP: c.auth.IP, Port: c.auth.Port + 1, Zone: c.auth.Zone}
+	c.secret = []byte(args[1])
+
+	if authFile == "" && acctFile == "" {
+		auth, err := parseTemplate(radius.Code_AccessRequest, "auth", defaultAuthTemplate)
+		if err != nil {
+			return err
+		}
+		acct, err := parseTemplate(radius.Code_AccountingRequest, "acct", defaultAcctTemplate)
+		if err != nil {
+			return err
+		}
+		c.templates = append(c.templates, auth, acct)
+	}
+	for _, f := range []struct {
+		code radius.PacketType
+		name string
+	}{{radius.Code_AccessRequest, authFile}, {radius.Code_AccountingRequest, acctFile}} {
+		if f.name == "" {
+			continue
+		}
+		text, err := os.ReadFile(f.name)
+		if err != nil {
+			return err
+		}
+		t, err := parseTemplate(f.code, f.name, string(text))
+		if err != nil {
+			return err
+		}
+		c.templates = append(c.templates, t)
+	}
+	if c.concurrency < 1 {
+		c.concurrency = 1
+	}
+	return nil
+}
+
+type requestTemplate struct {
+	code radius.PacketType
+	text *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"mod": func(n, m int) int { return n % m },
+	"hex": func(n int) string { return fmt.Sprintf("%08x", n) },
+}
+
+func parseTemplate(code radius.PacketType, name, text string) (*requestTemplate, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	rt := &requestTemplate{code: code, text: t}
+	// template must make valid attributes
+	if _, err := rt.request(0, 0, []byte("check")); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return rt, nil
+}
+
+// request makes packet of nth request, User-Password is given in plain text
+func (rt *requestTemplate) request(n, worker int, secret []byte) (*radius.Packet, error) {
+	var b strings.Builder
+	if err := rt.text.Execute(&b, struct{ N, Worker int }{n, worker}); err != nil {
+		return nil, err
+	}
+	attrs, err := radius.ParseAttributes(b.String())
+	if err != nil {
+		return nil, err
+	}
+	p := radius.NewPacket(rt.code, secret)
+	if p == nil {
+		return nil, errors.New("can't make packet identifier")
+	}
+	for _, a := range attrs {
+		if a.Type == radius.Attr_UserPassword && rt.code == radius.Code_AccessRequest {
+			password, _ := a.Value.(string)
+			if err := p.SetUserPassword(password); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		p.AddAttr(a)
+	}
+	return p, nil
+}
+
+// result is outcome of all requests
+type result struct {
+	elapsed   time.Duration
+	sent      int
+	codes     map[radius.PacketType]int
+	timeouts  int
+	errors    int
+	latencies []time.Duration
+	lastError error
+}
+
+func (c *config) run() *result {
+	res := &result{codes: make(map[radius.PacketType]int)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan int, c.concurrency)
+	for worker := 0; worker < c.concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for n := range jobs {
+				rt := c.templates[n%len(c.templates)]
+				dst := c.auth
+				if rt.code == radius.Code_AccountingRequest {
+					dst = c.acct
+				}
+				var reply *radius.Packet
+				// latency is of the exchange only, not of building the request
+				start := time.Now()
+				request, err := rt.request(n, worker, c.secret)
+				if err == nil {
+					start = time.Now()
+					reply, err = radius.ExchangePacket(request, dst, c.retries, c.timeout)
+				}
+				latency := time.Since(start)
+
+				mu.Lock()
+				switch {
+				case errors.Is(err, os.ErrDeadlineExceeded):
+					res.timeouts++
+				case err != nil:
+					res.errors++
+					res.lastError = err
+				default:
+					res.codes[reply.Type]++
+					res.latencies = append(res.latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}(worker)
+	}
+
+	start := time.Now()
+	var tick <-chan time.Time
+	if c.rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / c.rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	deadline := time.After(c.duration)
+	if c.total > 0 {
+		deadline = nil
+	}
+dispatch:
+	for n := 0; c.total == 0 || n < c.total; n++ {
+		if tick != nil {
+			select {
+			case <-tick:
+			case <-deadline:
+				break dispatch
+			}
+		}
+		select {
+		case jobs <- n:
+			res.sent++
+		case <-deadline:
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	res.elapsed = time.Since(start)
+	return res
+}
+
+// percentile of sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(p / 100 * float64(len(sorted)))
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+func (r *result) print(w io.Writer) {
+	sort.Slice(r.latencies, func(i, j int) bool { return r.latencies[i] < r.latencies[j] })
+	replies := len(r.latencies)
+	fmt.Fprintf(w, "requests:   %d in %s\n", r.sent, r.elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "throughput: %.1f replies/s\n", float64(replies)/r.elapsed.Seconds())
+
+	var codes []radius.PacketType
+	for code := range r.codes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	var counts []string
+	for _, code := range codes {
+		name := strings.TrimSuffix(code.String(), "("+strconv.Itoa(int(code))+")")
+		if name == "" {
+			name = "code " + strconv.Itoa(int(code))
+		}
+		counts = append(counts, fmt.Sprintf("%s %d", name, r.codes[code]))
+	}
+	fmt.Fprintf(w, "replies:    %s\n", strings.Join(counts, ", "))
+	fmt.Fprintf(w, "rejects:    %d\n", r.codes[radius.Code_AccessReject])
+	fmt.Fprintf(w, "timeouts:   %d\n", r.timeouts)
+	fmt.Fprintf(w, "errors:     %d\n", r.errors)
+	if r.lastError != nil {
+		fmt.Fprintf(w, "last error: %v\n", r.lastError)
+	}
+	if replies > 0 {
+		fmt.Fprintf(w, "latency:    p50 %s, p90 %s, p99 %s, max %s\n",
+			percentile(r.latencies, 50), percentile(r.latencies, 90), percentile(r.latencies, 99), r.latencies[replies-1])
+	}
+}
+
+// startLocal serves both addresses with Access-Accept and Accounting-Response
+func startLocal(auth, acct *net.UDPAddr, secret []byte) error {
+	handler := radius.HandlerFunc(func(ctx context.Context, w radius.ResponseWriter, r *radius.Request) {
+		code := radius.Code_AccessAccept
+		if r.Packet.Type == radius.Code_AccountingRequest {
+			code = radius.Code_AccountingResponse
+		}
+		w.Write(&radius.Packet{Type: code, Secret: secret})
+	})
+	errs := make(chan error, 2)
+	for _, addr := range []*net.UDPAddr{auth, acct} {
+		s := &radius.Server{Addr: addr.String(), Handler: handler}
+		go func() { errs <- s.ListenAndServe() }()
+	}
+
+	// wait until both answer
+	for i := 0; i < 50; i++ {
+		select {
+		case err := <-errs:
+			return fmt.Errorf("local server: %v", err)
+		default:
+		}
+		ready := true
+		for _, addr := range []*net.UDPAddr{auth, acct} {
+			probe := radius.NewPacket(radius.Code_StatusServer, secret)
+			probe.SignMessageAuthenticator = true
+			if _, err := radius.ExchangePacket(probe, addr, 1, 20*time.Millisecond); err != nil {
+				ready = false
+			}
+		}
+		if ready {
+			return nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return errors.New("local server doesn't answer")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+// freePort finds free port of 127.0.0.1 which next port is free too
+func freePort(t *testing.T) int {
+	for {
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		port := conn.LocalAddr().(*net.UDPAddr).Port
+		conn.Close()
+		if next, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port + 1}); err == nil {
+			next.Close()
+			return port
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var c config
+	acct := `User-Name = "user{{mod .N 3}}"
+Acct-Status-Type = Start
+Acct-Session-Id = "{{hex .N}}"
+`
+	if err := c.parseArgs([]string{net.JoinHostPort("127.0.0.1", strconv.Itoa(freePort(t))), "ctrhtn"}, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	at, err := parseTemplate(radius.Code_AccountingRequest, "acct", acct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.templates[1] = at
+	c.total, c.concurrency, c.retries, c.timeout = 40, 4, 2, time.Second
+	if err := startLocal(c.auth, c.acct, c.secret); err != nil {
+		t.Fatal(err)
+	}
+
+	res := c.run()
+	if res.sent != 40 || res.codes[radius.Code_AccessAccept] != 20 || res.codes[radius.Code_AccountingResponse] != 20 {
+		t.Errorf("Expected 20 Access-Accept and 20 Accounting-Response of 40 got %d %v (%v)", res.sent, res.codes, res.lastError)
+	}
+	var out bytes.Buffer
+	res.print(&out)
+	for _, s := range []string{"requests:   40", "replies:    Access-Accept 20, Accounting-Response 20", "latency:    p50 "} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("Expected %q in\n%s", s, out.String())
+		}
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	rt, err := parseTemplate(radius.Code_AccountingRequest, "acct", `Acct-Session-Id = "{{hex .N}}"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := rt.request(255, 0, []byte("ctrhtn"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := p.Attr(radius.Attr_AcctSessionId).Value; v != "000000ff" {
+		t.Errorf("Expected 000000ff got %v", v)
+	}
+	if _, err := parseTemplate(radius.Code_AccessRequest, "bad", `Bogus-Attribute = 1`); err == nil {
+		t.Error("Expected error of unknown attribute")
+	}
+}
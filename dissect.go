@@ -0,0 +1,220 @@
+package radius
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+/*
+Dissect prints every field of packet wire with its offset, bytes and meaning.
+Unlike Decode it doesn't stop at malformed data, such regions are marked with !!:
+
+	0000  01                       Code: Access-Request(1)
+	0001  07                       Identifier: 7
+	0002  00 22                    Length: 34
+	0004  9f 0c 12 7a 00 11 3b 4c  Authenticator: 9f0c127a00113b4c...
+	0014  01 05                    Attribute: User-Name(1), length 5
+	0016  62 6f 62                   Value: "bob"
+	0019  1a 09                    Attribute: Vendor-Specific(26), length 9
+	001b  00 00 00 09                Vendor-Id: Cisco(9)
+	001f  01 03                      VSA: Cisco-AVPair(1), length 3
+	0021  78                           Value: "x"
+*/
+func Dissect(wire []byte) string {
+	d := &dissector{wire: wire}
+	d.packet()
+	return d.b.String()
+}
+
+const dissectBytesShown = 8
+
+type dissector struct {
+	wire []byte
+	b    strings.Builder
+}
+
+// line prints field of wire[offset:offset+n] with indentation level
+func (d *dissector) line(offset, n, level int, format string, args ...interface{}) {
+	field := d.wire[offset : offset+n]
+	shown := field
+	if len(shown) > dissectBytesShown {
+		shown = shown[:dissectBytesShown]
+	}
+	var hexBytes []string
+	for _, c := range shown {
+		hexBytes = append(hexBytes, fmt.Sprintf("%02x", c))
+	}
+	fmt.Fprintf(&d.b, "%04x  %-23s  %s%s\n", offset, strings.Join(hexBytes, " "),
+		strings.Repeat("  ", level), fmt.Sprintf(format, args...))
+}
+
+// malformed marks wire[offset:offset+n] which can't be dissected
+func (d *dissector) malformed(offset, n, level int, format string, args ...interface{}) {
+	if n == 0 {
+		fmt.Fprintf(&d.b, "%04x  %-23s  %s!! %s\n", offset, "", strings.Repeat("  ", level), fmt.Sprintf(format, args...))
+		return
+	}
+	d.line(offset, n, level, "!! "+format, args...)
+}
+
+func (d *dissector) packet() {
+	wire := d.wire
+	if len(wire) < MinPacketLength {
+		d.malformed(0, len(wire), 0, "packet is shorter than header: %d bytes", len(wire))
+		return
+	}
+	code := PacketType(wire[0])
+	codeName := code.String()
+	if codeName == "" {
+		codeName = fmt.Sprintf("unknown(%d)", code)
+	}
+	d.line(0, 1, 0, "Code: %s", codeName)
+	d.line(1, 1, 0, "Identifier: %d", wire[1])
+
+	length := int(binary.BigEndian.Uint16(wire[2:4]))
+	end := length
+	switch {
+	case length < MinPacketLength || length > MaxPacketLength:
+		d.line(2, 2, 0, "Length: %d !! invalid, dissecting %d bytes received", length, len(wire))
+		end = len(wire)
+	case length > len(wire):
+		d.line(2, 2, 0, "Length: %d !! longer than %d bytes received", length, len(wire))
+		end = len(wire)
+	default:
+		d.line(2, 2, 0, "Length: %d", length)
+	}
+	d.line(4, 16, 0, "Authenticator: %x", wire[4:20])
+
+	offset := MinPacketLength
+	for offset < end {
+		n := d.attribute(offset, end)
+		if n == 0 {
+			return
+		}
+		offset += n
+	}
+	if end < len(wire) {
+		d.line(end, len(wire)-end, 0, "Padding: %d bytes after Length, ignored", len(wire)-end)
+	}
+}
+
+// attribute prints attribute at offset and returns its length, 0 if the rest of packet is malformed
+func (d *dissector) attribute(offset, end int) int {
+	if end-offset < 2 {
+		d.malformed(offset, end-offset, 0, "attribute header is truncated")
+		return 0
+	}
+	t := AttributeType(d.wire[offset])
+	length := int(d.wire[offset+1])
+	if length < 2 || offset+length > end {
+		d.line(offset, 2, 0, "Attribute: %s, length %d", t, length)
+		d.malformed(offset+2, end-offset-2, 1, "invalid attribute length %d, %d bytes left", length, end-offset)
+		return 0
+	}
+	d.line(offset, 2, 0, "Attribute: %s, length %d", t, length)
+	if t == Attr_VendorSpecific {
+		d.vendorSpecific(offset, length)
+		return length
+	}
+
+	a := &Attribute{Type: t, Wire: d.wire[offset : offset+length], Encoder: DefaultEncoder}
+	ai, known := attrTypeToInfo[t]
+	if known {
+		a.Encoder = ai.Encoder
+	}
+	valueOffset := offset + 2
+	value := a.Wire[2:]
+	if known && ai.Flags.Tagged && len(value) > 0 &&
+		(ai.Flags.Encrypt != 0 || ai.DataType == DataType_Integer || value[0] <= 0x1f) {
+		d.line(valueOffset, 1, 1, "Tag: %d", value[0])
+		valueOffset++
+		value = value[1:]
+	}
+	if err := a.Decode(); err != nil {
+		d.malformed(valueOffset, len(value), 1, "Value: %v", err)
+		return length
+	}
+	if len(value) == 0 {
+		d.malformed(valueOffset, 0, 1, "Value: empty")
+		return length
+	}
+	d.line(valueOffset, len(value), 1, "Value: %s", dissectValue(a.Value, value, attrTypeToValues[t]))
+	return length
+}
+
+func (d *dissector) vendorSpecific(offset, length int) {
+	end := offset + length
+	offset += 2
+	if end-offset < 4 {
+		d.malformed(offset, end-offset, 1, "vendor id is truncated")
+		return
+	}
+	vendorId := binary.BigEndian.Uint32(d.wire[offset:])
+	vendor := VendorName(vendorId)
+	if vendor == "" {
+		vendor = "unknown"
+	}
+	d.line(offset, 4, 1, "Vendor-Id: %s(%d)", vendor, vendorId)
+
+	for offset += 4; offset < end; {
+		if end-offset < 2 {
+			d.malformed(offset, end-offset, 1, "vsa header is truncated")
+			return
+		}
+		vendorType := d.wire[offset]
+		vsaLength := int(d.wire[offset+1])
+		name := textVSAName(vendorId, vendorType)
+		d.line(offset, 2, 1, "VSA: %s(%d), length %d", name, vendorType, vsaLength)
+		if vsaLength < 2 || offset+vsaLength > end {
+			d.malformed(offset+2, end-offset-2, 2, "invalid vsa length %d, %d bytes left", vsaLength, end-offset)
+			return
+		}
+
+		vsa := &VSA{VendorType: vendorType, Value: d.wire[offset+2 : offset+vsaLength]}
+		switch ai, known := lookupVSA(vendorId, vendorType); {
+		case len(vsa.Value) == 0:
+			d.malformed(offset+2, 0, 2, "Value: empty")
+		case !known:
+			d.line(offset+2, len(vsa.Value), 2, "Value: 0x%x", vsa.Value)
+		default:
+			if err := ai.Encoder.Decode(vsa); err != nil {
+				d.malformed(offset+2, len(vsa.Value), 2, "Value: %v", err)
+				break
+			}
+			d.line(offset+2, len(vsa.Value), 2, "Value: %s",
+				dissectValue(vsa.Data, vsa.Value, vsaTypeToValues[vendorId][vendorType]))
+		}
+		offset += vsaLength
+	}
+}
+
+// dissectValue is textValue with decoded structures printed by fmt
+func dissectValue(value interface{}, wire []byte, values *attrValues) string {
+	text := textValue(value, wire, values)
+	switch value.(type) {
+	case nil, []byte, string:
+		return text
+	}
+	if strings.HasPrefix(text, "0x") {
+		return fmt.Sprintf("%+v (%s)", value, text)
+	}
+	return text
+}
+
+// ParseHex reads hex dump as printed by tcpdump, Wireshark or xxd -p: whitespace, colons
+// and 0x prefix are skipped, offsets at line starts of "0000:" or "0x0000:" form are dropped
+func ParseHex(dump string) ([]byte, error) {
+	var digits strings.Builder
+	for _, line := range strings.Split(dump, "\n") {
+		if i := strings.IndexByte(line, ':'); i >= 0 && i <= 10 && strings.Count(line, ":") == 1 {
+			line = line[i+1:]
+		}
+		for _, field := range strings.Fields(line) {
+			field = strings.TrimPrefix(field, "0x")
+			digits.WriteString(strings.ReplaceAll(field, ":", ""))
+		}
+	}
+	return hex.DecodeString(digits.String())
+}
@@ -0,0 +1,58 @@
+package radius
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDissect(t *testing.T) {
+	p := NewPacket(Code_AccessAccept, []byte("ctrhtn"))
+	p.Identifier = 7
+	p.AddAttribute(Attr_UserName, "bob")
+	tunnelType := MustNewAttribute(Attr_TunnelType, TunnelType_VLAN)
+	tunnelType.Tag = 1
+	p.AddAttr(tunnelType)
+	vendor, _ := NewVendorAttribute(Vendor_Cisco)
+	vendor.AddVSA(VSA_CiscoAVPair, "x")
+	p.AddAttr(vendor)
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := Dissect(p.Wire)
+	for _, s := range []string{
+		"0000  02                       Code: Access-Accept(2)\n",
+		"0001  07                       Identifier: 7\n",
+		"0014  01 05                    Attribute: User-Name(1), length 5\n",
+		"0016  62 6f 62                   Value: \"bob\"\n",
+		"001b  01                         Tag: 1\n",
+		"001c  00 00 0d                   Value: VLAN\n",
+		"0021  00 00 00 09                Vendor-Id: Cisco(9)\n",
+		"0025  01 03                      VSA: Cisco-AVPair(1), length 3\n",
+		"0027  78                           Value: \"x\"\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %q in\n%s", s, out)
+		}
+	}
+
+	// Session-Timeout of 3 bytes and truncated attribute after it
+	wire := append([]byte{}, p.Wire[:20]...)
+	wire = append(wire, byte(Attr_SessionTimeout), 5, 0, 0, 1, byte(Attr_UserName), 9, 'a')
+	wire[3] = byte(len(wire))
+	out = Dissect(wire)
+	for _, s := range []string{"!! Value: integer Attribute has invalid size", "!! invalid attribute length 9, 3 bytes left"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("Expected %q in\n%s", s, out)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	for _, dump := range []string{"0102 0304", "01:02:03:04", "0x0000:  0102 0304", "01020304\n"} {
+		b, err := ParseHex(dump)
+		if err != nil || string(b) != "\x01\x02\x03\x04" {
+			t.Errorf("Expected 01020304 of %q got %x (%v)", dump, b, err)
+		}
+	}
+}
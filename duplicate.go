@@ -0,0 +1,148 @@
+package radius
+
+import (
+	"container/list"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDuplicateLifetime is how long reply is replayed to retransmissions, rfc 5080 2.2.2
+	// suggests it be longer than retransmission period of NAS
+	DefaultDuplicateLifetime = 30 * time.Second
+	// DefaultDuplicateMaxEntries bounds memory of DuplicateCache
+	DefaultDuplicateMaxEntries = 65536
+)
+
+/*
+DuplicateCache detects retransmitted requests by source address, port, Identifier and Request
+Authenticator as rfc 5080 2.2.2 recommends. Duplicates of request which is still served are dropped,
+later duplicates get the cached reply without running the handler again. Request left without
+reply is forgotten, so its retransmission is served as a new request. When the cache is full
+of requests still served, new requests are dropped as their retransmissions couldn't be detected.
+*/
+type DuplicateCache struct {
+	// Lifetime of entry after its request is served, DefaultDuplicateLifetime if 0
+	Lifetime time.Duration
+	// MaxEntries bounds number of entries, served entries are evicted first by the time they
+	// are served, DefaultDuplicateMaxEntries if 0
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[duplicateKey]*list.Element
+	// inFlight are entries of requests being served by insertion
+	inFlight list.List
+	// served are entries of served requests by the time they are served, so by expiration
+	served list.List
+}
+
+// errDuplicatesFull is returned by begin when all entries are in flight
+var errDuplicatesFull = errors.New("radius: duplicate cache is full of requests in flight")
+
+type duplicateKey struct {
+	addr          string
+	identifier    byte
+	authenticator [16]byte
+}
+
+type duplicateEntry struct {
+	key    duplicateKey
+	served bool
+	// expires is set when request is served
+	expires time.Time
+	reply   []byte
+}
+
+func newDuplicateKey(addr *net.UDPAddr, p *Packet) duplicateKey {
+	return duplicateKey{addr: addr.String(), identifier: p.Identifier, authenticator: p.Authenticator}
+}
+
+// begin registers request, for duplicate it returns true and cached reply if request is served.
+// New request is refused with errDuplicatesFull if all entries are in flight
+func (c *DuplicateCache) begin(key duplicateKey, now time.Time) (reply []byte, duplicate bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[duplicateKey]*list.Element)
+	}
+	c.expire(now)
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*duplicateEntry)
+		return entry.reply, true, nil
+	}
+
+	maxEntries := c.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultDuplicateMaxEntries
+	}
+	for len(c.entries) >= maxEntries {
+		if c.served.Len() == 0 {
+			return nil, false, errDuplicatesFull
+		}
+		c.remove(c.served.Front())
+	}
+	c.entries[key] = c.inFlight.PushBack(&duplicateEntry{key: key})
+	return nil, false, nil
+}
+
+// done caches reply of served request
+func (c *DuplicateCache) done(key duplicateKey, reply []byte, now time.Time) {
+	lifetime := c.Lifetime
+	if lifetime <= 0 {
+		lifetime = DefaultDuplicateLifetime
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		// evicted while served
+		return
+	}
+	entry := e.Value.(*duplicateEntry)
+	if entry.served {
+		return
+	}
+	c.inFlight.Remove(e)
+	entry.served = true
+	entry.reply = reply
+	entry.expires = now.Add(lifetime)
+	c.entries[key] = c.served.PushBack(entry)
+}
+
+// forget removes request which wasn't served
+func (c *DuplicateCache) forget(key duplicateKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// expire removes expired entries of served requests, entries in flight are kept
+func (c *DuplicateCache) expire(now time.Time) {
+	for e := c.served.Front(); e != nil; e = c.served.Front() {
+		if now.Before(e.Value.(*duplicateEntry).expires) {
+			return
+		}
+		c.remove(e)
+	}
+}
+
+func (c *DuplicateCache) remove(e *list.Element) {
+	entry := e.Value.(*duplicateEntry)
+	if entry.served {
+		c.served.Remove(e)
+	} else {
+		c.inFlight.Remove(e)
+	}
+	delete(c.entries, entry.key)
+}
+
+// Len is number of requests in the cache
+func (c *DuplicateCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,165 @@
+package radius
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDuplicateCache(t *testing.T) {
+	c := &DuplicateCache{Lifetime: time.Second, MaxEntries: 2}
+	now := time.Now()
+	addr := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1000}
+	p := &Packet{Identifier: 1}
+	key := newDuplicateKey(addr, p)
+
+	if _, duplicate, err := c.begin(key, now); duplicate || err != nil {
+		t.Fatalf("Expected new request got %v %v", duplicate, err)
+	}
+	if reply, duplicate, _ := c.begin(key, now); !duplicate || reply != nil {
+		t.Errorf("Expected duplicate in flight got %v %x", duplicate, reply)
+	}
+	c.done(key, []byte("reply"), now)
+	if reply, duplicate, _ := c.begin(key, now.Add(time.Second/2)); !duplicate || string(reply) != "reply" {
+		t.Errorf("Expected cached reply got %v %q", duplicate, reply)
+	}
+	if _, duplicate, _ := c.begin(key, now.Add(2*time.Second)); duplicate {
+		t.Error("Expected expired entry")
+	}
+}
+
+func TestDuplicateCache_EvictServed(t *testing.T) {
+	c := &DuplicateCache{Lifetime: time.Minute, MaxEntries: 2}
+	now := time.Now()
+	addr := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1000}
+	inFlight := newDuplicateKey(addr, &Packet{Identifier: 1})
+	served := newDuplicateKey(addr, &Packet{Identifier: 2})
+	c.begin(inFlight, now)
+	c.begin(served, now)
+	c.done(served, []byte("reply"), now)
+
+	// served entry is evicted though it's newer
+	if _, _, err := c.begin(newDuplicateKey(addr, &Packet{Identifier: 3}), now); err != nil {
+		t.Fatal(err)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries got %d", c.Len())
+	}
+	if _, duplicate, _ := c.begin(inFlight, now); !duplicate {
+		t.Error("Expected entry in flight to be kept")
+	}
+	if _, duplicate, _ := c.begin(served, now); duplicate {
+		t.Error("Expected served entry to be evicted")
+	}
+}
+
+func TestDuplicateCache_FullInFlight(t *testing.T) {
+	c := &DuplicateCache{Lifetime: time.Second, MaxEntries: 2}
+	now := time.Now()
+	addr := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1000}
+	first := newDuplicateKey(addr, &Packet{Identifier: 1})
+	second := newDuplicateKey(addr, &Packet{Identifier: 2})
+	third := newDuplicateKey(addr, &Packet{Identifier: 3})
+	c.begin(first, now)
+	c.begin(second, now)
+	c.done(second, []byte("reply"), now)
+
+	// served entry expires behind the older one in flight
+	later := now.Add(2 * time.Second)
+	if _, duplicate, err := c.begin(second, later); duplicate || err != nil {
+		t.Errorf("Expected expired entry got %v %v", duplicate, err)
+	}
+	if _, _, err := c.begin(third, later); err != errDuplicatesFull {
+		t.Errorf("Expected errDuplicatesFull got %v", err)
+	}
+	if _, duplicate, _ := c.begin(first, later); !duplicate {
+		t.Error("Expected entry in flight to be kept")
+	}
+	c.forget(first)
+	if _, _, err := c.begin(third, later); err != nil {
+		t.Errorf("Expected room after forget got %v", err)
+	}
+}
+
+func TestServer_Duplicates(t *testing.T) {
+	secret := []byte("ctrhtn")
+	var calls int32
+	release := make(chan struct{})
+	s := &Server{
+		Duplicates: &DuplicateCache{},
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			w.Write(&Packet{Type: Code_AccountingResponse, Secret: secret})
+		}),
+	}
+	addr, _ := startServer(t, s)
+
+	request := NewPacket(Code_AccountingRequest, secret)
+	request.AddAttribute(Attr_AcctSessionId, "s1")
+	if err := request.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := net.DialUDP("udp4", nil, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	read := func() []byte {
+		buff := make([]byte, MaxPacketLength)
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, err := conn.Read(buff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return buff[:n]
+	}
+
+	// retransmission while in flight is dropped
+	conn.Write(request.Wire)
+	conn.Write(request.Wire)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	first := read()
+
+	// retransmission after reply gets the same reply
+	conn.Write(request.Wire)
+	if replayed := read(); !bytes.Equal(first, replayed) {
+		t.Errorf("Expected replayed reply %x got %x", first, replayed)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected handler to run once got %d", n)
+	}
+}
+
+func TestServer_DuplicatesUnanswered(t *testing.T) {
+	secret := []byte("ctrhtn")
+	var calls int32
+	s := &Server{
+		Duplicates: &DuplicateCache{},
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			// the first request is left without reply
+			if atomic.AddInt32(&calls, 1) > 1 {
+				w.Write(&Packet{Type: Code_AccountingResponse, Secret: secret})
+			}
+		}),
+	}
+	addr, _ := startServer(t, s)
+
+	request := NewPacket(Code_AccountingRequest, secret)
+	request.AddAttribute(Attr_AcctSessionId, "s1")
+	if err := request.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	wire, err := Exchange(request.Wire, addr, nil, 3, 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := &Packet{Wire: wire}
+	if err := reply.Decode(); err != nil || reply.Type != Code_AccountingResponse {
+		t.Errorf("Expected Accounting-Response to retransmission got %v (%v)", reply.Type, err)
+	}
+}
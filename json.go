@@ -0,0 +1,371 @@
+package radius
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+	JSON form of packet for logs and test fixtures:
+
+	{"code": "Accounting-Request", "identifier": 7, "authenticator": "9f0c...",
+	 "attributes": [
+		{"name": "User-Name", "type": 1, "value": "bob"},
+		{"name": "Acct-Status-Type", "type": 40, "value": "Start"},
+		{"name": "Tunnel-Type", "type": 64, "tag": 1, "value": "VLAN"},
+		{"name": "Class", "type": 25, "hex": "00ff"},
+		{"name": "User-Password", "type": 2, "redacted": true},
+		{"name": "Vendor-Specific", "type": 26, "vendor_id": 9, "vendor": "Cisco",
+		 "vsa": [{"name": "Cisco-AVPair", "type": 1, "value": "ip:vrf-id=internet"}]}
+	 ]}
+
+	Values are typed by dictionary: integers are numbers or value names, dates are rfc 3339
+	strings, octets and binary strings are hex of the attribute value (with the tag octet of
+	tagged attributes). Values decoded to structures are written with their hex as well.
+	Secret is never written, so it has to be set on rebuilt packet before Encode. Rebuilt
+	Access-Request and Status-Server with authenticator get KeepAuthenticator, so they are
+	encoded to the same wire.
+*/
+
+// JSONPacket is the JSON form of Packet
+type JSONPacket struct {
+	Code                 string           `json:"code"`
+	Identifier           uint8            `json:"identifier"`
+	Authenticator        string           `json:"authenticator"`
+	RequestAuthenticator string           `json:"request_authenticator,omitempty"`
+	Attributes           []*JSONAttribute `json:"attributes,omitempty"`
+}
+
+// JSONAttribute is the JSON form of Attribute, Vendor-Specific attribute has vendor fields and VSA
+type JSONAttribute struct {
+	Name     string          `json:"name,omitempty"`
+	Type     uint8           `json:"type"`
+	Tag      uint8           `json:"tag,omitempty"`
+	Value    interface{}     `json:"value,omitempty"`
+	Hex      string          `json:"hex,omitempty"`
+	Redacted bool            `json:"redacted,omitempty"`
+	VendorId uint32          `json:"vendor_id,omitempty"`
+	Vendor   string          `json:"vendor,omitempty"`
+	VSA      []*JSONVSA      `json:"vsa,omitempty"`
+	raw      json.RawMessage // Value to be decoded by dictionary type
+}
+
+// JSONVSA is the JSON form of VSA
+type JSONVSA struct {
+	Name     string          `json:"name,omitempty"`
+	Type     uint8           `json:"type"`
+	Value    interface{}     `json:"value,omitempty"`
+	Hex      string          `json:"hex,omitempty"`
+	Redacted bool            `json:"redacted,omitempty"`
+	raw      json.RawMessage // Value to be decoded by dictionary type
+}
+
+// packetCode returns code of the packet name
+func packetCode(name string) (PacketType, bool) {
+	for code, n := range packetName {
+		if n == name {
+			return code, true
+		}
+	}
+	return 0, false
+}
+
+// MarshalJSON writes packet redacted with DefaultRedactionPolicy, use NewJSONPacket to keep values
+func (p *Packet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(NewJSONPacket(p, DefaultRedactionPolicy))
+}
+
+// UnmarshalJSON rebuilds packet from its JSON form, redacted attributes are an error
+func (p *Packet) UnmarshalJSON(b []byte) error {
+	jp := new(JSONPacket)
+	if err := json.Unmarshal(b, jp); err != nil {
+		return err
+	}
+	packet, err := jp.Packet()
+	if err != nil {
+		return err
+	}
+	*p = *packet
+	return nil
+}
+
+// NewJSONPacket makes JSON form of the packet, values are hidden by the policy, nil policy keeps all
+func NewJSONPacket(p *Packet, policy *RedactionPolicy) *JSONPacket {
+	jp := &JSONPacket{
+		Code:          strconv.Itoa(int(p.Type)),
+		Identifier:    p.Identifier,
+		Authenticator: hex.EncodeToString(p.Authenticator[:]),
+	}
+	if name, ok := packetName[p.Type]; ok {
+		jp.Code = name
+	}
+	if p.RequestAuthenticator != [16]byte{} {
+		jp.RequestAuthenticator = hex.EncodeToString(p.RequestAuthenticator[:])
+	}
+	for _, a := range p.Attributes {
+		jp.Attributes = append(jp.Attributes, newJSONAttribute(a, policy))
+	}
+	return jp
+}
+
+func newJSONAttribute(a *Attribute, policy *RedactionPolicy) *JSONAttribute {
+	ja := &JSONAttribute{Name: a.Type.Name(), Type: uint8(a.Type)}
+	ai := attrTypeToInfo[a.Type]
+	switch {
+	case policy.RedactsAttribute(a.Type):
+		ja.Redacted = true
+	case a.Type == Attr_VendorSpecific:
+		ja.VendorId, _ = a.VendorId()
+		ja.Vendor = VendorName(ja.VendorId)
+		for _, vsa := range a.Pairs {
+			jv := &JSONVSA{Name: VSAName(ja.VendorId, vsa.VendorType), Type: vsa.VendorType}
+			if policy.RedactsVSA(ja.VendorId, vsa.VendorType) {
+				jv.Redacted = true
+				ja.VSA = append(ja.VSA, jv)
+				continue
+			}
+			jv.Value, jv.Hex = jsonValue(vsa.Data, vsa.Value, vsaTypeToValues[ja.VendorId][vsa.VendorType])
+			ja.VSA = append(ja.VSA, jv)
+		}
+	case ai.Flags.Encrypt != 0:
+		ja.Hex = hex.EncodeToString(attrValueWire(a))
+	default:
+		if ai.Flags.Tagged {
+			ja.Tag = a.Tag
+		}
+		ja.Value, ja.Hex = jsonValue(a.Value, attrValueWire(a), attrTypeToValues[a.Type])
+	}
+	return ja
+}
+
+// attrValueWire returns attribute value on the wire, encoding it if needed
+func attrValueWire(a *Attribute) []byte {
+	if len(a.Wire) < 2 {
+		if err := a.Encode(); err != nil || len(a.Wire) < 2 {
+			return nil
+		}
+	}
+	return a.Wire[2:]
+}
+
+// jsonValue returns typed JSON value or hex of the wire for values which can't be typed
+func jsonValue(value interface{}, wire []byte, values *attrValues) (interface{}, string) {
+	switch v := value.(type) {
+	case nil, []byte:
+		return nil, hex.EncodeToString(wire)
+	case string:
+		if !utf8.ValidString(v) {
+			return nil, hex.EncodeToString(wire)
+		}
+		return v, ""
+	case uint32, uint64:
+		return v, ""
+	case EnumValue:
+		if values != nil {
+			if name, ok := values.names[v.Uint32()]; ok {
+				return name, ""
+			}
+		}
+		return v.Uint32(), ""
+	case AttributeValue:
+		return uint32(v), ""
+	case net.IP:
+		return v.String(), ""
+	case *net.IPNet:
+		return v.String(), ""
+	case time.Time:
+		return v.UTC().Format(time.RFC3339), ""
+	}
+	// decoded structures are informative only, hex keeps the value
+	return value, hex.EncodeToString(wire)
+}
+
+// UnmarshalJSON keeps value undecoded until dictionary type of the attribute is known
+func (ja *JSONAttribute) UnmarshalJSON(b []byte) error {
+	type jsonAttribute JSONAttribute
+	var v struct {
+		*jsonAttribute
+		Value json.RawMessage `json:"value"`
+	}
+	v.jsonAttribute = (*jsonAttribute)(ja)
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	ja.raw = v.Value
+	return nil
+}
+
+func (jv *JSONVSA) UnmarshalJSON(b []byte) error {
+	type jsonVSA JSONVSA
+	var v struct {
+		*jsonVSA
+		Value json.RawMessage `json:"value"`
+	}
+	v.jsonVSA = (*jsonVSA)(jv)
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	jv.raw = v.Value
+	return nil
+}
+
+// Packet rebuilds the packet, attributes are encoded and decoded back as if packet was received
+func (jp *JSONPacket) Packet() (*Packet, error) {
+	p := &Packet{Identifier: jp.Identifier}
+	if code, ok := packetCode(jp.Code); ok {
+		p.Type = code
+	} else if code, err := strconv.ParseUint(jp.Code, 10, 8); err == nil {
+		p.Type = PacketType(code)
+	} else {
+		return nil, fmt.Errorf("radius: unknown packet code %q", jp.Code)
+	}
+	if err := decodeAuthenticator(jp.Authenticator, &p.Authenticator); err != nil {
+		return nil, fmt.Errorf("radius: authenticator: %v", err)
+	}
+	// Encode must not replace authenticator of fixture and retransmitted request
+	p.KeepAuthenticator = p.Authenticator != [16]byte{}
+	if err := decodeAuthenticator(jp.RequestAuthenticator, &p.RequestAuthenticator); err != nil {
+		return nil, fmt.Errorf("radius: request authenticator: %v", err)
+	}
+	for _, ja := range jp.Attributes {
+		a, err := ja.attribute()
+		if err != nil {
+			return nil, fmt.Errorf("radius: attribute %s(%d): %v", ja.Name, ja.Type, err)
+		}
+		p.AddAttr(a)
+	}
+	return p, nil
+}
+
+func decodeAuthenticator(s string, authenticator *[16]byte) error {
+	if s == "" {
+		return nil
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != len(authenticator) {
+		return fmt.Errorf("must be %d bytes long", len(authenticator))
+	}
+	copy(authenticator[:], b)
+	return nil
+}
+
+func (ja *JSONAttribute) attribute() (*Attribute, error) {
+	t := AttributeType(ja.Type)
+	if ja.Name != "" {
+		var ok bool
+		if t, ok = AttributeTypeByName(ja.Name); !ok {
+			return nil, errors.New("unknown attribute name")
+		}
+	}
+	if ja.Redacted {
+		return nil, errors.New("value is redacted")
+	}
+	a := &Attribute{Type: t, Encoder: DefaultEncoder}
+	ai, known := attrTypeToInfo[t]
+	if known {
+		a.Encoder = ai.Encoder
+	}
+
+	switch {
+	case ja.Hex != "":
+		wire, err := hex.DecodeString(ja.Hex)
+		if err != nil {
+			return nil, err
+		}
+		a.Value = wire
+		if err = DefaultEncoder.Encode(a); err != nil {
+			return nil, err
+		}
+	case t == Attr_VendorSpecific:
+		a.Value = ja.VendorId
+		for _, jv := range ja.VSA {
+			vsa, err := jv.vsa(ja.VendorId)
+			if err != nil {
+				return nil, fmt.Errorf("vsa %s(%d): %v", jv.Name, jv.Type, err)
+			}
+			a.Pairs = append(a.Pairs, vsa)
+		}
+		if err := a.Encode(); err != nil {
+			return nil, err
+		}
+	case !known:
+		return nil, errors.New("unknown attribute needs hex value")
+	default:
+		value, err := parseJSONValue(ai.DataType, attrTypeToValues[t], ja.raw)
+		if err != nil {
+			return nil, err
+		}
+		a.Value = value
+		a.Tag = ja.Tag
+		if err = a.Encode(); err != nil {
+			return nil, err
+		}
+	}
+	// make value the same as decoded one
+	if err := a.Decode(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (jv *JSONVSA) vsa(vendorId uint32) (*VSA, error) {
+	vendorType := jv.Type
+	if jv.Name != "" {
+		var ok bool
+		if vendorType, ok = VSATypeByName(vendorId, jv.Name); !ok {
+			return nil, errors.New("unknown vendor attribute name")
+		}
+	}
+	if jv.Redacted {
+		return nil, errors.New("value is redacted")
+	}
+	vsa := &VSA{VendorType: vendorType}
+	ai, known := lookupVSA(vendorId, vendorType)
+	switch {
+	case jv.Hex != "":
+		wire, err := hex.DecodeString(jv.Hex)
+		if err != nil {
+			return nil, err
+		}
+		vsa.Value = wire
+	case !known:
+		return nil, errors.New("unknown vendor attribute needs hex value")
+	default:
+		value, err := parseJSONValue(ai.DataType, vsaTypeToValues[vendorId][vendorType], jv.raw)
+		if err != nil {
+			return nil, err
+		}
+		vsa.Data = value
+		if err = ai.Encoder.Encode(vsa); err != nil {
+			return nil, err
+		}
+	}
+	if known {
+		if err := ai.Encoder.Decode(vsa); err != nil {
+			return nil, err
+		}
+	}
+	return vsa, nil
+}
+
+// parseJSONValue makes attribute value of the dictionary type from JSON string or number
+func parseJSONValue(dataType DataType, values *attrValues, raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("no value")
+	}
+	value := textToken{text: string(raw)}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		value = textToken{text: s, quoted: true}
+	}
+	return parseTextValue(dataType, values, value)
+}
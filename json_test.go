@@ -0,0 +1,117 @@
+package radius
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPacket_JSON(t *testing.T) {
+	secret := []byte("ctrhtn")
+	p := NewPacket(Code_AccountingRequest, secret)
+	p.AddAttribute(Attr_UserName, "bob")
+	p.AddAttribute(Attr_AcctStatusType, AcctStatusType_Start)
+	p.AddAttribute(Attr_NASIPAddress, net.IPv4(10, 0, 0, 1))
+	p.AddAttribute(Attr_Class, string([]byte{0, 0xff}))
+	p.AddAttribute(Attr_EventTimestamp, time.Unix(1542844800, 0))
+	tunnelType := MustNewAttribute(Attr_TunnelType, TunnelType_VLAN)
+	tunnelType.Tag = 1
+	p.AddAttr(tunnelType)
+	vendor, _ := NewVendorAttribute(Vendor_Cisco)
+	vendor.AddVSA(VSA_CiscoAVPair, "ip:vrf-id=internet")
+	p.AddAttr(vendor)
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"code":"Accounting-Request"`, `"value":"Start"`, `"hex":"00ff"`,
+		`"tag":1,"value":"VLAN"`, `"value":"2018-11-22T00:00:00Z"`, `"vendor":"Cisco"`, `"value":"ip:vrf-id=internet"`} {
+		if !strings.Contains(string(b), s) {
+			t.Errorf("Expected %s in %s", s, b)
+		}
+	}
+
+	out := new(Packet)
+	if err = json.Unmarshal(b, out); err != nil {
+		t.Fatal(err)
+	}
+	out.Secret = secret
+	if err = out.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p.Wire, out.Wire) {
+		t.Errorf("Expected %x got %x", p.Wire, out.Wire)
+	}
+}
+
+func TestPacket_JSONAccessRequest(t *testing.T) {
+	secret := []byte("ctrhtn")
+	for _, code := range []PacketType{Code_AccessRequest, Code_StatusServer} {
+		p := NewPacket(code, secret)
+		p.AddAttribute(Attr_NASIdentifier, "nas")
+		p.SignMessageAuthenticator = true
+		if err := p.Encode(); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := new(Packet)
+		if err = json.Unmarshal(b, out); err != nil {
+			t.Fatal(err)
+		}
+		out.Secret = secret
+		if err = out.Encode(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p.Wire, out.Wire) {
+			t.Errorf("%s: Expected %x got %x", code, p.Wire, out.Wire)
+		}
+	}
+}
+
+func TestPacket_JSONRedacted(t *testing.T) {
+	p := NewPacket(Code_AccessRequest, []byte("ctrhtn"))
+	p.AddAttribute(Attr_UserName, "bob")
+	p.AddAttribute(Attr_UserPassword, "s3cr3t")
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "s3cr3t") || strings.Contains(string(b), "ctrhtn") ||
+		!strings.Contains(string(b), `"redacted":true`) {
+		t.Errorf("Expected redacted password in %s", b)
+	}
+	if err = json.Unmarshal(b, new(Packet)); err == nil {
+		t.Error("Expected error of redacted attribute")
+	}
+
+	// fixtures keep the password and Access-Request keeps its authenticator when asked
+	if b, err = json.Marshal(NewJSONPacket(p, nil)); err != nil {
+		t.Fatal(err)
+	}
+	out := new(Packet)
+	if err = json.Unmarshal(b, out); err != nil {
+		t.Fatal(err)
+	}
+	out.Secret = p.Secret
+	if err = out.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(p.Wire, out.Wire) {
+		t.Errorf("Expected %x got %x", p.Wire, out.Wire)
+	}
+}
@@ -0,0 +1,81 @@
+package radius
+
+import (
+	"net"
+)
+
+/*
+Listener is set of addresses of Server accepting requests of the given codes, e.g. server of
+authentication, accounting and dynamic authorization ports:
+
+	s := &Server{
+		Handler: handler,
+		Listeners: []*Listener{
+			AuthListener("0.0.0.0:1812", "[::]:1812"),
+			AcctListener("0.0.0.0:1813", "[::]:1813"),
+		},
+	}
+*/
+type Listener struct {
+	// Addrs to bind, ":1812" if empty
+	Addrs []string
+	// Network of addresses: "udp", "udp4" or "udp6", "udp" if empty
+	Network string
+	// Codes of requests served by the listener, others are dropped. Any code if empty
+	Codes []PacketType
+}
+
+// AuthListener accepts Access-Request and Status-Server
+func AuthListener(addrs ...string) *Listener {
+	return &Listener{Addrs: addrs, Codes: []PacketType{Code_AccessRequest, Code_StatusServer}}
+}
+
+// AcctListener accepts Accounting-Request and Status-Server
+func AcctListener(addrs ...string) *Listener {
+	return &Listener{Addrs: addrs, Codes: []PacketType{Code_AccountingRequest, Code_StatusServer}}
+}
+
+// CoAListener accepts CoA-Request and Disconnect-Request, rfc 5176
+func CoAListener(addrs ...string) *Listener {
+	return &Listener{Addrs: addrs, Codes: []PacketType{Code_CoARequest, Code_DisconnectRequest}}
+}
+
+func (l *Listener) allows(code PacketType) bool {
+	if len(l.Codes) == 0 {
+		return true
+	}
+	for _, c := range l.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// listen binds all addresses, nothing stays bound on error
+func (l *Listener) listen() ([]*net.UDPConn, error) {
+	network := l.Network
+	if network == "" {
+		network = "udp"
+	}
+	addrs := l.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{":1812"}
+	}
+	conns := make([]*net.UDPConn, 0, len(addrs))
+	for _, addrStr := range addrs {
+		addr, err := net.ResolveUDPAddr(network, addrStr)
+		if err == nil {
+			var conn *net.UDPConn
+			if conn, err = net.ListenUDP(network, addr); err == nil {
+				conns = append(conns, conn)
+				continue
+			}
+		}
+		for _, conn := range conns {
+			conn.Close()
+		}
+		return nil, err
+	}
+	return conns, nil
+}
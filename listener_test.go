@@ -0,0 +1,112 @@
+package radius
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func freeUDPPort(t *testing.T, network string, ip net.IP) int {
+	conn, err := net.ListenUDP(network, &net.UDPAddr{IP: ip})
+	if err != nil {
+		t.Skipf("%s is not available: %v", network, err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func waitBound(t *testing.T, network string, addr *net.UDPAddr) {
+	for i := 0; i < 50; i++ {
+		c, err := net.ListenUDP(network, addr)
+		if err != nil {
+			return
+		}
+		c.Close()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s isn't bound", addr)
+}
+
+func TestServer_Listeners(t *testing.T) {
+	secret := []byte("ctrhtn")
+	authPort := freeUDPPort(t, "udp4", net.IPv4(127, 0, 0, 1))
+	acctPort := freeUDPPort(t, "udp4", net.IPv4(127, 0, 0, 1))
+	freeUDPPort(t, "udp6", net.IPv6loopback)
+	auth := AuthListener(
+		net.JoinHostPort("127.0.0.1", strconv.Itoa(authPort)),
+		net.JoinHostPort("::1", strconv.Itoa(authPort)),
+	)
+	acct := AcctListener(net.JoinHostPort("127.0.0.1", strconv.Itoa(acctPort)))
+
+	s := &Server{
+		Listeners: []*Listener{auth, acct},
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			reply := &Packet{Type: Code_AccessAccept, Secret: secret}
+			if r.Packet.Type == Code_AccountingRequest {
+				reply.Type = Code_AccountingResponse
+				if r.Listener != acct {
+					t.Errorf("Expected accounting listener got %+v", r.Listener)
+				}
+			}
+			w.Write(reply)
+		}),
+	}
+	errs := make(chan error, 1)
+	go func() { errs <- s.ListenAndServe() }()
+	waitBound(t, "udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: acctPort})
+
+	for _, c := range []struct {
+		code PacketType
+		addr *net.UDPAddr
+		ok   bool
+	}{
+		{Code_AccessRequest, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: authPort}, true},
+		{Code_AccessRequest, &net.UDPAddr{IP: net.IPv6loopback, Port: authPort}, true},
+		{Code_AccountingRequest, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: acctPort}, true},
+		{Code_AccountingRequest, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: authPort}, false},
+		{Code_AccessRequest, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: acctPort}, false},
+	} {
+		request := NewPacket(c.code, secret)
+		_, err := ExchangePacket(request, c.addr, 1, 200*time.Millisecond)
+		if c.ok && err != nil {
+			t.Errorf("%s to %s: Expected reply got %v", c.code, c.addr, err)
+		}
+		if !c.ok && err == nil {
+			t.Errorf("%s to %s: Expected request to be dropped", c.code, c.addr)
+		}
+	}
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != ErrServerClosed {
+		t.Errorf("Expected ErrServerClosed got %v", err)
+	}
+}
+
+func TestServer_ListenerBindError(t *testing.T) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	free := freeUDPPort(t, "udp4", net.IPv4(127, 0, 0, 1))
+	freeAddr := net.JoinHostPort("127.0.0.1", strconv.Itoa(free))
+
+	s := &Server{
+		Listeners: []*Listener{AuthListener(freeAddr, conn.LocalAddr().String())},
+		Handler:   HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {}),
+	}
+	if err := s.ListenAndServe(); err == nil {
+		t.Fatal("Expected error of bound address")
+	}
+	// address bound before the error is released
+	c, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: free})
+	if err != nil {
+		t.Errorf("Expected %s to be released got %v", freeAddr, err)
+	} else {
+		c.Close()
+	}
+}
@@ -0,0 +1,174 @@
+package radius
+
+import (
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+)
+
+const redactedValue = "[redacted]"
+
+// RedactionPolicy is set of values hidden in logs and JSON of packets
+type RedactionPolicy struct {
+	// Secret hides the shared secret of packet
+	Secret     bool
+	Attributes map[AttributeType]bool
+	// VSAs are vendor attributes by vendor id and type
+	VSAs map[uint32]map[uint8]bool
+}
+
+// DefaultRedactionPolicy hides secret, passwords, State and MPPE keys
+var DefaultRedactionPolicy = &RedactionPolicy{
+	Secret: true,
+	Attributes: map[AttributeType]bool{
+		Attr_UserPassword:   true,
+		Attr_CHAPPassword:   true,
+		Attr_TunnelPassword: true,
+		Attr_ARAPPassword:   true,
+		Attr_State:          true,
+	},
+	VSAs: map[uint32]map[uint8]bool{
+		Vendor_Microsoft: {
+			VSA_MSMPPESendKey:  true,
+			VSA_MSMPPERecvKey:  true,
+			VSA_MSCHAPMPPEKeys: true,
+		},
+	},
+}
+
+// RedactsAttribute reports whether value of the attribute is hidden
+func (r *RedactionPolicy) RedactsAttribute(t AttributeType) bool {
+	return r != nil && r.Attributes[t]
+}
+
+// RedactsVSA reports whether value of the vendor attribute is hidden
+func (r *RedactionPolicy) RedactsVSA(vendorId uint32, vendorType uint8) bool {
+	return r != nil && r.VSAs[vendorId][vendorType]
+}
+
+// RedactsSecret reports whether the shared secret is hidden
+func (r *RedactionPolicy) RedactsSecret() bool {
+	return r != nil && r.Secret
+}
+
+// LogValue makes structured log record of packet with DefaultRedactionPolicy
+func (p *Packet) LogValue() slog.Value {
+	return p.Redacted(DefaultRedactionPolicy).LogValue()
+}
+
+// Redacted returns packet for slog with the redaction policy, nil policy hides nothing
+func (p *Packet) Redacted(policy *RedactionPolicy) slog.LogValuer {
+	return &redactedPacket{p, policy}
+}
+
+type redactedPacket struct {
+	p      *Packet
+	policy *RedactionPolicy
+}
+
+/*
+LogValue is group of code, id, authenticator and attributes keyed by dictionary names,
+repeated attributes are lists and vendor attributes are keyed by their own names:
+
+code=Access-Request id=7 authenticator=9f0c... attributes.User-Name=bob
+attributes.User-Password=[redacted] attributes.Cisco-AVPair="[ip:vrf-id=internet ip:addr-pool=x]"
+*/
+func (rp *redactedPacket) LogValue() slog.Value {
+	p := rp.p
+	code := fmt.Sprintf("%d", p.Type)
+	if name, ok := packetName[p.Type]; ok {
+		code = name
+	}
+	attrs := []slog.Attr{
+		slog.String("code", code),
+		slog.Int("id", int(p.Identifier)),
+		slog.String("authenticator", hex.EncodeToString(p.Authenticator[:])),
+	}
+	if len(p.Secret) > 0 {
+		if rp.policy.RedactsSecret() {
+			attrs = append(attrs, slog.String("secret", redactedValue))
+		} else {
+			attrs = append(attrs, slog.String("secret", string(p.Secret)))
+		}
+	}
+
+	var names []string
+	values := make(map[string][]interface{})
+	add := func(name string, value interface{}) {
+		if _, ok := values[name]; !ok {
+			names = append(names, name)
+		}
+		values[name] = append(values[name], value)
+	}
+	for _, a := range p.Attributes {
+		if a.Type != Attr_VendorSpecific {
+			add(textTaggedName(a), rp.attrValue(a))
+			continue
+		}
+		vendorId, _ := a.VendorId()
+		for _, vsa := range a.Pairs {
+			name := textVSAName(vendorId, vsa.VendorType)
+			if rp.policy.RedactsVSA(vendorId, vsa.VendorType) {
+				add(name, redactedValue)
+				continue
+			}
+			add(name, logValue(jsonValue(vsa.Data, vsa.Value, vsaTypeToValues[vendorId][vsa.VendorType])))
+		}
+	}
+
+	if len(names) > 0 {
+		group := make([]slog.Attr, 0, len(names))
+		for _, name := range names {
+			if v := values[name]; len(v) == 1 {
+				group = append(group, slog.Any(name, v[0]))
+			} else {
+				group = append(group, slog.Any(name, v))
+			}
+		}
+		attrs = append(attrs, slog.Attr{Key: "attributes", Value: slog.GroupValue(group...)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (rp *redactedPacket) attrValue(a *Attribute) interface{} {
+	if rp.policy.RedactsAttribute(a.Type) {
+		return redactedValue
+	}
+	return logValue(jsonValue(a.Value, attrValueWire(a), attrTypeToValues[a.Type]))
+}
+
+// logValue prefers typed value of jsonValue, hex is written as 0x hex
+func logValue(value interface{}, hexValue string) interface{} {
+	if value == nil {
+		return "0x" + hexValue
+	}
+	return value
+}
+
+// secretString is secret of packet for String
+func (r *RedactionPolicy) secretString(p *Packet) string {
+	if r.RedactsSecret() {
+		return redactedValue
+	}
+	return string(p.Secret)
+}
+
+// attrString is value of attribute for String
+func (r *RedactionPolicy) attrString(a *Attribute) string {
+	if r.RedactsAttribute(a.Type) {
+		return redactedValue
+	}
+	return fmt.Sprintf("%+v", a.Value)
+}
+
+// vsaString is vendor attribute for String
+func (r *RedactionPolicy) vsaString(vendorId uint32, vsa *VSA) string {
+	name := textVSAName(vendorId, vsa.VendorType)
+	switch {
+	case r.RedactsVSA(vendorId, vsa.VendorType):
+		return fmt.Sprintf("%s: %s", name, redactedValue)
+	case vsa.Data != nil:
+		return fmt.Sprintf("%s: %+v", name, vsa.Data)
+	}
+	return fmt.Sprintf("%s: %q", name, vsa.Value)
+}
@@ -0,0 +1,69 @@
+package radius
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestPacket_LogValue(t *testing.T) {
+	p := NewPacket(Code_AccessAccept, []byte("ctrhtn"))
+	p.Identifier = 7
+	p.AddAttribute(Attr_UserName, "bob")
+	p.AddAttribute(Attr_State, "state")
+	p.AddAttribute(Attr_ServiceType, ServiceType_FramedUser)
+	p.AddAttribute(Attr_Class, "a")
+	p.AddAttribute(Attr_Class, "b")
+	ms, _ := NewVendorAttribute(Vendor_Microsoft)
+	ms.AddVSA(VSA_MSMPPESendKey, []byte("key"))
+	ms.AddVSA(VSA_MSMPPEEncryptionPolicy, MSMPPEEncryptionPolicy_EncryptionRequired)
+	p.AddAttr(ms)
+
+	var b bytes.Buffer
+	slog.New(slog.NewJSONHandler(&b, nil)).Info("reply", "packet", p)
+	var record struct {
+		Packet struct {
+			Code       string
+			Id         int
+			Secret     string
+			Attributes map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(b.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	got := record.Packet
+	if got.Code != "Access-Accept" || got.Id != 7 || got.Secret != redactedValue {
+		t.Errorf("Unexpected %+v", got)
+	}
+	for name, value := range map[string]interface{}{
+		"User-Name":                 "bob",
+		"State":                     redactedValue,
+		"Service-Type":              "Framed-User",
+		"MS-MPPE-Send-Key":          redactedValue,
+		"MS-MPPE-Encryption-Policy": "Encryption-Required",
+	} {
+		if got.Attributes[name] != value {
+			t.Errorf("Expected %s=%v got %v", name, value, got.Attributes[name])
+		}
+	}
+	if class, ok := got.Attributes["Class"].([]interface{}); !ok || len(class) != 2 {
+		t.Errorf("Expected 2 Class values got %v", got.Attributes["Class"])
+	}
+
+	b.Reset()
+	slog.New(slog.NewTextHandler(&b, nil)).Info("reply", "packet", p.Redacted(nil))
+	if !strings.Contains(b.String(), "packet.secret=ctrhtn") || !strings.Contains(b.String(), "packet.attributes.State=state") {
+		t.Errorf("Expected values without redaction in %s", b.String())
+	}
+
+	str := p.String() + p.StringMultiLine()
+	if strings.Contains(str, "ctrhtn") || strings.Contains(str, "state") || strings.Contains(str, "key") {
+		t.Errorf("Expected redacted values in %s", str)
+	}
+	if !strings.Contains(str, "VId: 311 MS-MPPE-Encryption-Policy: Encryption-Required(2)") {
+		t.Errorf("Expected vendor attribute in %s", str)
+	}
+}
@@ -0,0 +1,269 @@
+package radius
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+	Marshal and Unmarshal map attributes to struct fields tagged with dictionary names:
+
+	type Session struct {
+		UserName string         `radius:"User-Name"`
+		NASIP    net.IP         `radius:"NAS-IP-Address"`
+		Status   AcctStatusType `radius:"Acct-Status-Type"`
+		Timeout  *uint32        `radius:"Session-Timeout"`
+		Class    [][]byte       `radius:"Class"`
+		AVPairs  []string       `radius:"Cisco-AVPair,vendor=9"`
+		Reason   string         `radius:"Reply-Message,omitempty"`
+	}
+
+	Slices collect every attribute of the type ([]byte and net.IP are single values),
+	single fields get the last attribute as Packet.Attr does. Unmarshal leaves fields of
+	missing attributes as they are, so pointers of zero struct stay nil if packet has no
+	attribute. Marshal skips empty strings, byte slices and zero time.Time with or without
+	omitempty as attributes can't be empty, rfc 2865 5.
+*/
+
+type fieldTag struct {
+	name       string
+	vendor     bool
+	vendorId   uint32
+	vendorType uint8
+	attrType   AttributeType
+	omitEmpty  bool
+}
+
+func parseFieldTag(tag string) (ft fieldTag, err error) {
+	parts := strings.Split(tag, ",")
+	ft.name = parts[0]
+	for _, opt := range parts[1:] {
+		switch {
+		case opt == "omitempty":
+			ft.omitEmpty = true
+		case strings.HasPrefix(opt, "vendor="):
+			id, err := strconv.ParseUint(strings.TrimPrefix(opt, "vendor="), 10, 32)
+			if err != nil {
+				return ft, fmt.Errorf("invalid vendor of %s: %v", ft.name, err)
+			}
+			ft.vendor = true
+			ft.vendorId = uint32(id)
+		default:
+			return ft, fmt.Errorf("unknown option %q of %s", opt, ft.name)
+		}
+	}
+	var ok bool
+	if ft.vendor {
+		if ft.vendorType, ok = VSATypeByName(ft.vendorId, ft.name); !ok {
+			return ft, fmt.Errorf("unknown vendor attribute %s of vendor %d", ft.name, ft.vendorId)
+		}
+	} else if ft.attrType, ok = AttributeTypeByName(ft.name); !ok {
+		return ft, fmt.Errorf("unknown attribute %s", ft.name)
+	}
+	return ft, nil
+}
+
+// isMultiValued reports slice fields which collect all attributes of the type
+func isMultiValued(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// Unmarshal stores values of packet attributes in the struct pointed to by v
+func Unmarshal(p *Packet, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("radius: unmarshal needs non-nil pointer to struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag, ok := sf.Tag.Lookup("radius")
+		if !ok || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+		ft, err := parseFieldTag(tag)
+		if err != nil {
+			return fmt.Errorf("radius: field %s: %v", sf.Name, err)
+		}
+		if err = setField(rv.Field(i), packetValues(p, ft)); err != nil {
+			return fmt.Errorf("radius: field %s: %v", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// packetValues returns decoded values of attributes matching the tag in order of appearance
+func packetValues(p *Packet, ft fieldTag) []interface{} {
+	var values []interface{}
+	if ft.vendor {
+		for _, vsa := range p.VSAs(ft.vendorId, ft.vendorType) {
+			if vsa.Data != nil {
+				values = append(values, vsa.Data)
+			} else {
+				values = append(values, vsa.Value)
+			}
+		}
+		return values
+	}
+	for _, a := range p.Attrs(ft.attrType) {
+		values = append(values, a.Value)
+	}
+	return values
+}
+
+func setField(field reflect.Value, values []interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+	switch {
+	case isMultiValued(field.Type()):
+		out := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(out.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(out)
+	case field.Kind() == reflect.Ptr:
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), values[len(values)-1]); err != nil {
+			return err
+		}
+		field.Set(elem)
+	default:
+		return setValue(field, values[len(values)-1])
+	}
+	return nil
+}
+
+func setValue(dst reflect.Value, value interface{}) error {
+	src := reflect.ValueOf(value)
+	switch {
+	case !src.IsValid():
+		return fmt.Errorf("can't assign empty value to %s", dst.Type())
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case src.Kind() == dst.Kind() && src.Type().ConvertibleTo(dst.Type()):
+		// named types such as enumerations and their underlying types
+		dst.Set(src.Convert(dst.Type()))
+	case src.Kind() == reflect.String && dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+		// string attributes may carry binary data
+		dst.SetBytes([]byte(src.String()))
+	default:
+		return fmt.Errorf("can't assign %T to %s", value, dst.Type())
+	}
+	return nil
+}
+
+// Marshal makes attributes of tagged fields of struct v, every vendor
+// attribute is placed in its own Vendor-Specific attribute
+func Marshal(v interface{}) ([]*Attribute, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("radius: marshal needs struct or pointer to struct")
+	}
+	rt := rv.Type()
+	var attrs []*Attribute
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag, ok := sf.Tag.Lookup("radius")
+		if !ok || tag == "-" || sf.PkgPath != "" {
+			continue
+		}
+		ft, err := parseFieldTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("radius: field %s: %v", sf.Name, err)
+		}
+		for _, value := range fieldValues(rv.Field(i), ft.omitEmpty) {
+			a, err := marshalValue(ft, value)
+			if err != nil {
+				return nil, fmt.Errorf("radius: field %s: %v", sf.Name, err)
+			}
+			attrs = append(attrs, a)
+		}
+	}
+	return attrs, nil
+}
+
+func fieldValues(field reflect.Value, omitEmpty bool) []interface{} {
+	switch {
+	case isMultiValued(field.Type()):
+		values := make([]interface{}, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			if !isEmptyValue(field.Index(i)) {
+				values = append(values, encoderValue(field.Index(i)))
+			}
+		}
+		return values
+	case field.Kind() == reflect.Ptr:
+		if field.IsNil() || isEmptyValue(field.Elem()) {
+			return nil
+		}
+		return []interface{}{encoderValue(field.Elem())}
+	case omitEmpty && field.IsZero(), isEmptyValue(field):
+		return nil
+	}
+	return []interface{}{encoderValue(field)}
+}
+
+// isEmptyValue reports strings, byte slices and zero time which can't be attribute values
+func isEmptyValue(v reflect.Value) bool {
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.IsZero()
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		return v.Len() == 0
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// encoderValue converts named strings and integers to the types accepted by encoders
+func encoderValue(v reflect.Value) interface{} {
+	value := v.Interface()
+	if _, ok := value.(EnumValue); ok {
+		return value
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Uint32:
+		return uint32(v.Uint())
+	case reflect.Uint64:
+		return v.Uint()
+	}
+	return value
+}
+
+func marshalValue(ft fieldTag, value interface{}) (*Attribute, error) {
+	if ft.vendor {
+		a, err := NewVendorAttribute(ft.vendorId)
+		if err != nil {
+			return nil, err
+		}
+		if err = a.AddVSA(ft.vendorType, value); err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+	a, err := NewAttribute(ft.attrType)
+	if err != nil {
+		return nil, err
+	}
+	a.Value = value
+	// encode now to report invalid values with the field name
+	if err = a.Encode(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,114 @@
+package radius
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testSession struct {
+	UserName  string         `radius:"User-Name"`
+	NASIP     net.IP         `radius:"NAS-IP-Address"`
+	Status    AcctStatusType `radius:"Acct-Status-Type"`
+	Timestamp time.Time      `radius:"Event-Timestamp"`
+	Timeout   *uint32        `radius:"Session-Timeout"`
+	Class     [][]byte       `radius:"Class"`
+	AVPairs   []string       `radius:"Cisco-AVPair,vendor=9"`
+	Reply     string         `radius:"Reply-Message,omitempty"`
+	Ignored   string
+}
+
+func TestMarshal(t *testing.T) {
+	timeout := uint32(3600)
+	in := testSession{
+		UserName:  "ctrhtn",
+		NASIP:     net.IPv4(10, 0, 0, 1).To4(),
+		Status:    AcctStatusType_InterimUpdate,
+		Timestamp: time.Unix(1542844800, 0).UTC(),
+		Timeout:   &timeout,
+		Class:     [][]byte{{1, 2}, {3}},
+		AVPairs:   []string{"subscriber:accounting-list=default", "ip:vrf-id=internet"},
+		Ignored:   "x",
+	}
+	attrs, err := Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 9 {
+		t.Fatalf("Expected 9 attributes got %d", len(attrs))
+	}
+
+	p := NewPacket(Code_AccountingRequest, []byte("ctrhtn"))
+	p.AddAttrs(attrs...)
+	if err = p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	req := &Packet{Wire: p.Wire}
+	if err = req.Decode(); err != nil {
+		t.Fatal(err)
+	}
+
+	var out testSession
+	if err = Unmarshal(req, &out); err != nil {
+		t.Fatal(err)
+	}
+	in.Ignored = ""
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("Expected %+v got %+v", in, out)
+	}
+}
+
+func TestMarshal_Empty(t *testing.T) {
+	var empty struct {
+		UserName string    `radius:"User-Name"`
+		NASIP    net.IP    `radius:"NAS-IP-Address"`
+		State    []byte    `radius:"State"`
+		Class    [][]byte  `radius:"Class"`
+		Reply    *string   `radius:"Reply-Message"`
+		Status   uint32    `radius:"Acct-Status-Type"`
+		Time     time.Time `radius:"Event-Timestamp"`
+	}
+	reply := ""
+	empty.Class = [][]byte{nil, {1}}
+	empty.Reply = &reply
+	attrs, err := Marshal(empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 2 || attrs[0].Type != Attr_Class || attrs[1].Type != Attr_AcctStatusType {
+		t.Errorf("Expected Class and Acct-Status-Type got %v", attrs)
+	}
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	p := NewPacket(Code_AccessRequest, nil)
+	p.AddAttribute(Attr_UserName, "ctrhtn")
+
+	var wrongType struct {
+		UserName uint32 `radius:"User-Name"`
+	}
+	if err := Unmarshal(p, &wrongType); err == nil {
+		t.Error("Expected error of string assigned to uint32")
+	}
+	var unknown struct {
+		Foo string `radius:"Foo-Bar"`
+	}
+	if err := Unmarshal(p, &unknown); err == nil {
+		t.Error("Expected error of unknown attribute")
+	}
+	if err := Unmarshal(p, unknown); err == nil {
+		t.Error("Expected error of non pointer")
+	}
+
+	var optional struct {
+		UserName *string `radius:"User-Name"`
+		Timeout  *uint32 `radius:"Session-Timeout"`
+	}
+	if err := Unmarshal(p, &optional); err != nil {
+		t.Fatal(err)
+	}
+	if optional.UserName == nil || *optional.UserName != "ctrhtn" || optional.Timeout != nil {
+		t.Errorf("Unexpected %+v", optional)
+	}
+}
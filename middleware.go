@@ -0,0 +1,167 @@
+package radius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// ErrLateReply is returned by ResponseWriter of Deadline when request deadline is exceeded
+var ErrLateReply = errors.New("radius: reply after request deadline")
+
+// Middleware wraps handler
+type Middleware func(Handler) Handler
+
+/*
+Chain wraps handler with middlewares, the first one is the outermost:
+
+	s.Handler = radius.Chain(mux,
+		radius.Recovery(radius.RecoveryPolicy_Reject),
+		radius.AccessLog(nil, radius.DefaultRedactionPolicy),
+		radius.Deadline(5*time.Second),
+	)
+*/
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// RecoveryPolicy chooses reply to request whose handler panics
+type RecoveryPolicy int
+
+const (
+	// RecoveryPolicy_Reject replies Access-Reject, CoA-NAK or Disconnect-NAK, other requests are dropped
+	RecoveryPolicy_Reject RecoveryPolicy = iota
+	// RecoveryPolicy_Drop drops request
+	RecoveryPolicy_Drop
+)
+
+// Recovery logs panic of handler and replies according to policy, reply written before the panic stays
+func Recovery(policy RecoveryPolicy) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				l.Errorf(" packet handler panic: %v %s from %s\n%s", rec, r.Packet.Type, r.RemoteAddr, debug.Stack())
+				if policy == RecoveryPolicy_Reject {
+					if reply := rejectOf(r.Packet); reply != nil {
+						w.Write(reply)
+					}
+				}
+			}()
+			next.ServeRADIUS(ctx, w, r)
+		})
+	}
+}
+
+// rejectOf is negative reply to the request, nil if its code has none
+func rejectOf(request *Packet) *Packet {
+	switch request.Type {
+	case Code_AccessRequest:
+		return &Packet{Type: Code_AccessReject}
+	case Code_CoARequest, Code_DisconnectRequest:
+		nak, _ := NewNAK(request, ErrorCause_ResourcesUnavailable)
+		return nak
+	}
+	return nil
+}
+
+/*
+AccessLog logs every request with its reply and latency since Request.Start, packets are logged in
+redacted form of policy. Nil logger is slog.Default.
+*/
+func AccessLog(logger *slog.Logger, policy *RedactionPolicy) Middleware {
+	return Latency(func(r *Request, reply *Packet, latency time.Duration) {
+		log := logger
+		if log == nil {
+			log = slog.Default()
+		}
+		attrs := []interface{}{
+			slog.String("remote", r.RemoteAddr.String()),
+			slog.Any("request", r.Packet.Redacted(policy)),
+		}
+		if reply != nil {
+			attrs = append(attrs, slog.Any("reply", reply.Redacted(policy)))
+		} else {
+			attrs = append(attrs, slog.String("reply", "none"))
+		}
+		attrs = append(attrs, slog.Duration("latency", latency))
+		log.Info("radius request", attrs...)
+	})
+}
+
+/*
+Latency calls observe when handler returns with reply written by handler, nil if there is none.
+Latency of written reply is measured from Request.Start to its Write, so it includes time
+the request waited in Server queue.
+*/
+func Latency(observe func(r *Request, reply *Packet, latency time.Duration)) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			rw := &recordingWriter{ResponseWriter: w}
+			next.ServeRADIUS(ctx, rw, r)
+			if rw.reply == nil {
+				rw.written = time.Now()
+			}
+			observe(r, rw.reply, rw.written.Sub(r.Start))
+		})
+	}
+}
+
+// recordingWriter keeps reply which is written successfully
+type recordingWriter struct {
+	ResponseWriter
+	reply   *Packet
+	written time.Time
+}
+
+func (w *recordingWriter) Write(reply *Packet) error {
+	err := w.ResponseWriter.Write(reply)
+	if err == nil {
+		w.reply = reply
+		w.written = time.Now()
+	}
+	return err
+}
+
+/*
+Deadline sets deadline of handler context to timeout since Request.Start and refuses replies written
+after the context is done with ErrLateReply: NAS has retransmitted or given up the request by then.
+Zero timeout keeps deadline of Server.RequestTimeout.
+*/
+func Deadline(timeout time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, r.Start.Add(timeout))
+				defer cancel()
+			}
+			next.ServeRADIUS(ctx, &deadlineWriter{ResponseWriter: w, ctx: ctx, request: r}, r)
+		})
+	}
+}
+
+type deadlineWriter struct {
+	ResponseWriter
+	ctx     context.Context
+	request *Request
+}
+
+func (w *deadlineWriter) Write(reply *Packet) error {
+	if err := w.ctx.Err(); err != nil {
+		l.Warnf(" packet late reply: %s %s to %s", time.Since(w.request.Start), reply.Type, w.request.RemoteAddr)
+		return fmt.Errorf("%w: %v", ErrLateReply, err)
+	}
+	return w.ResponseWriter.Write(reply)
+}
@@ -0,0 +1,138 @@
+package radius
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testWriter keeps written replies
+type testWriter struct {
+	replies []*Packet
+}
+
+func (w *testWriter) Write(reply *Packet) error {
+	w.replies = append(w.replies, reply)
+	return nil
+}
+
+func (w *testWriter) LocalAddr() net.Addr  { return nil }
+func (w *testWriter) RemoteAddr() net.Addr { return nil }
+
+func testRequest(code PacketType) *Request {
+	return &Request{
+		Start:      time.Now(),
+		RemoteAddr: &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1000},
+		Packet:     NewPacket(code, []byte("ctrhtn")),
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	named := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+				order = append(order, name)
+				next.ServeRADIUS(ctx, w, r)
+			})
+		}
+	}
+	h := Chain(HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+		order = append(order, "handler")
+	}), named("outer"), named("inner"))
+	h.ServeRADIUS(context.Background(), &testWriter{}, testRequest(Code_AccessRequest))
+	if got := strings.Join(order, " "); got != "outer inner handler" {
+		t.Errorf("Expected outer inner handler got %s", got)
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	panics := HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) { panic("boom") })
+	for _, c := range []struct {
+		policy RecoveryPolicy
+		code   PacketType
+		reply  PacketType
+	}{
+		{RecoveryPolicy_Reject, Code_AccessRequest, Code_AccessReject},
+		{RecoveryPolicy_Reject, Code_CoARequest, Code_CoANAK},
+		{RecoveryPolicy_Reject, Code_DisconnectRequest, Code_DisconnectNAK},
+		{RecoveryPolicy_Reject, Code_AccountingRequest, 0},
+		{RecoveryPolicy_Drop, Code_AccessRequest, 0},
+	} {
+		w := &testWriter{}
+		Recovery(c.policy)(panics).ServeRADIUS(context.Background(), w, testRequest(c.code))
+		switch {
+		case c.reply == 0 && len(w.replies) != 0:
+			t.Errorf("%d %s: Expected no reply got %s", c.policy, c.code, w.replies[0].Type)
+		case c.reply != 0 && (len(w.replies) != 1 || w.replies[0].Type != c.reply):
+			t.Errorf("%d %s: Expected %s got %v", c.policy, c.code, c.reply, w.replies)
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	var writeErr error
+	h := Deadline(20 * time.Millisecond)(HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+		<-ctx.Done()
+		writeErr = w.Write(&Packet{Type: Code_AccessAccept})
+	}))
+	w := &testWriter{}
+	start := time.Now()
+	h.ServeRADIUS(context.Background(), w, testRequest(Code_AccessRequest))
+	if !errors.Is(writeErr, ErrLateReply) {
+		t.Errorf("Expected ErrLateReply got %v", writeErr)
+	}
+	if len(w.replies) != 0 {
+		t.Errorf("Expected late reply to be dropped got %v", w.replies)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected deadline of 20ms got %s", elapsed)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	buff := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buff, nil))
+	h := AccessLog(logger, DefaultRedactionPolicy)(HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+		w.Write(&Packet{Type: Code_AccessAccept})
+	}))
+	r := testRequest(Code_AccessRequest)
+	r.Start = r.Start.Add(-time.Second)
+	r.Packet.AddAttribute(Attr_UserName, "bob")
+	r.Packet.AddAttribute(Attr_UserPassword, "secret")
+	h.ServeRADIUS(context.Background(), &testWriter{}, r)
+
+	line := buff.String()
+	for _, want := range []string{
+		"remote=127.0.0.1:1000",
+		"request.attributes.User-Name=bob",
+		"request.attributes.User-Password=[redacted]",
+		"reply.code=Access-Accept",
+	} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %s in %s", want, line)
+		}
+	}
+	if strings.Contains(line, "ctrhtn") {
+		t.Errorf("Expected redacted secret in %s", line)
+	}
+}
+
+func TestLatency(t *testing.T) {
+	var observed time.Duration
+	var observedReply *Packet
+	h := Latency(func(r *Request, reply *Packet, latency time.Duration) {
+		observed, observedReply = latency, reply
+	})(HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {}))
+	r := testRequest(Code_AccessRequest)
+	r.Start = r.Start.Add(-time.Second)
+	h.ServeRADIUS(context.Background(), &testWriter{}, r)
+	if observed < time.Second || observedReply != nil {
+		t.Errorf("Expected latency since Request.Start without reply got %s %v", observed, observedReply)
+	}
+}
@@ -0,0 +1,140 @@
+package radius
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	l "github.com/sirupsen/logrus"
+)
+
+// Matcher reports whether request matches route of ServeMux
+type Matcher func(r *Request) bool
+
+/*
+ServeMux dispatches requests to handlers by packet code and attributes. Routes are tried in the
+order they are registered and the first one matching the request serves it, so specific routes
+are registered before general routes of the same code:
+
+	mux := &radius.ServeMux{}
+	mux.HandleFunc(radius.Code_AccountingRequest, stop, radius.MatchAcctStatusType(radius.AcctStatusType_Stop))
+	mux.Handle(radius.Code_AccountingRequest, accounting)
+	mux.Handle(radius.Code_AccessRequest, corporate, radius.MatchRealm("corp.example.com"))
+	mux.Handle(radius.Code_AccessRequest, subscribers)
+	mux.HandleDefault(unknown)
+
+Requests matching no route are served by the default handler, without one they are dropped.
+*/
+type ServeMux struct {
+	mu       sync.RWMutex
+	routes   []muxRoute
+	fallback Handler
+}
+
+type muxRoute struct {
+	code     PacketType
+	matchers []Matcher
+	handler  Handler
+}
+
+// Handle adds route of requests of code matching all matchers
+func (m *ServeMux) Handle(code PacketType, handler Handler, matchers ...Matcher) {
+	if handler == nil {
+		panic("radius: nil handler")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes = append(m.routes, muxRoute{code: code, matchers: matchers, handler: handler})
+}
+
+// HandleFunc adds route of handler function
+func (m *ServeMux) HandleFunc(code PacketType, handler func(context.Context, ResponseWriter, *Request), matchers ...Matcher) {
+	m.Handle(code, HandlerFunc(handler), matchers...)
+}
+
+// HandleDefault sets handler of requests matching no route
+func (m *ServeMux) HandleDefault(handler Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fallback = handler
+}
+
+// Handler returns handler of request, nil if request is dropped
+func (m *ServeMux) Handler(r *Request) Handler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, route := range m.routes {
+		if route.match(r) {
+			return route.handler
+		}
+	}
+	return m.fallback
+}
+
+// ServeRADIUS dispatches request to handler of the first matching route
+func (m *ServeMux) ServeRADIUS(ctx context.Context, w ResponseWriter, r *Request) {
+	handler := m.Handler(r)
+	if handler == nil {
+		l.Warnf(" packet %s has no route from %s", r.Packet.Type, r.RemoteAddr)
+		return
+	}
+	handler.ServeRADIUS(ctx, w, r)
+}
+
+func (route *muxRoute) match(r *Request) bool {
+	if r.Packet.Type != route.code {
+		return false
+	}
+	for _, match := range route.matchers {
+		if !match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchAcctStatusType matches requests of any of Acct-Status-Type values
+func MatchAcctStatusType(types ...AcctStatusType) Matcher {
+	return func(r *Request) bool {
+		a := r.Packet.Attr(Attr_AcctStatusType)
+		if a == nil {
+			return false
+		}
+		value, ok := uint32Value(a.Value)
+		if !ok {
+			return false
+		}
+		for _, t := range types {
+			if uint32(t) == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchNASIdentifier matches requests of NAS-Identifier matching the expression
+func MatchNASIdentifier(re *regexp.Regexp) Matcher {
+	return func(r *Request) bool {
+		a := r.Packet.Attr(Attr_NASIdentifier)
+		if a == nil {
+			return false
+		}
+		nasId, _ := a.Value.(string)
+		return re.MatchString(nasId)
+	}
+}
+
+// MatchRealm matches requests of User-Name with "@realm" suffix, realm is case insensitive
+func MatchRealm(realm string) Matcher {
+	suffix := "@" + strings.ToLower(realm)
+	return func(r *Request) bool {
+		a := r.Packet.Attr(Attr_UserName)
+		if a == nil {
+			return false
+		}
+		userName, _ := a.Value.(string)
+		return strings.HasSuffix(strings.ToLower(userName), suffix)
+	}
+}
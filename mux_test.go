@@ -0,0 +1,59 @@
+package radius
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestServeMux(t *testing.T) {
+	var served string
+	named := func(name string) HandlerFunc {
+		return func(ctx context.Context, w ResponseWriter, r *Request) { served = name }
+	}
+	mux := &ServeMux{}
+	mux.Handle(Code_AccountingRequest, named("stop"), MatchAcctStatusType(AcctStatusType_Stop, AcctStatusType_AccountingOff))
+	mux.Handle(Code_AccountingRequest, named("bras"), MatchNASIdentifier(regexp.MustCompile(`^bras\d+$`)))
+	mux.Handle(Code_AccountingRequest, named("acct"))
+	mux.Handle(Code_AccessRequest, named("corp"), MatchRealm("Corp.Example.com"), MatchNASIdentifier(regexp.MustCompile(`^vpn`)))
+	mux.Handle(Code_AccessRequest, named("auth"))
+
+	for _, c := range []struct {
+		code  PacketType
+		attrs map[AttributeType]interface{}
+		want  string
+	}{
+		{Code_AccountingRequest, map[AttributeType]interface{}{Attr_AcctStatusType: AcctStatusType_Stop, Attr_NASIdentifier: "bras1"}, "stop"},
+		{Code_AccountingRequest, map[AttributeType]interface{}{Attr_AcctStatusType: AcctStatusType_Start, Attr_NASIdentifier: "bras1"}, "bras"},
+		{Code_AccountingRequest, map[AttributeType]interface{}{Attr_AcctStatusType: AcctStatusType_Start, Attr_NASIdentifier: "bras1x"}, "acct"},
+		{Code_AccessRequest, map[AttributeType]interface{}{Attr_UserName: "bob@corp.example.COM", Attr_NASIdentifier: "vpn1"}, "corp"},
+		{Code_AccessRequest, map[AttributeType]interface{}{Attr_UserName: "bob@corp.example.com", Attr_NASIdentifier: "bras1"}, "auth"},
+		{Code_AccessRequest, map[AttributeType]interface{}{Attr_UserName: "bob@xcorp.example.com.org", Attr_NASIdentifier: "vpn1"}, "auth"},
+		{Code_CoARequest, nil, ""},
+	} {
+		p := NewPacket(c.code, []byte("ctrhtn"))
+		for t, v := range c.attrs {
+			p.AddAttribute(t, v)
+		}
+		// matchers see decoded values
+		if err := p.Encode(); err != nil {
+			t.Fatal(err)
+		}
+		decoded := &Packet{Wire: p.Wire, Secret: p.Secret}
+		if err := decoded.Decode(); err != nil {
+			t.Fatal(err)
+		}
+
+		served = ""
+		mux.ServeRADIUS(context.Background(), nil, &Request{Packet: decoded})
+		if served != c.want {
+			t.Errorf("%s %v: Expected %q got %q", c.code, c.attrs, c.want, served)
+		}
+	}
+
+	mux.HandleDefault(named("default"))
+	mux.ServeRADIUS(context.Background(), nil, &Request{Packet: &Packet{Type: Code_CoARequest}})
+	if served != "default" {
+		t.Errorf("Expected default handler got %q", served)
+	}
+}
@@ -47,6 +47,13 @@ type Packet struct {
 	// требуется для формирования аутентификатора ответа
 	RequestAuthenticator [16]byte
 	Secret               []byte
+	// KeepAuthenticator makes Encode keep non-zero Authenticator of Access-Request and Status-Server,
+	// otherwise every Encode makes new random one as rfc 2865 3 requires. It's set by SetUserPassword
+	// as the password is encrypted with the authenticator
+	KeepAuthenticator bool
+	// SignMessageAuthenticator makes Encode add Message-Authenticator if packet has none and sign it,
+	// otherwise value of Message-Authenticator is encoded as it is
+	SignMessageAuthenticator bool
 
 	Wire       []byte
 	Attributes []*Attribute
@@ -90,6 +97,11 @@ func (p *Packet) encodeAttributes() (err error) {
 
 func (p *Packet) Encode() error {
 
+	if p.SignMessageAuthenticator && p.Attr(Attr_MessageAuthenticator) == nil {
+		if err := p.AddAttribute(Attr_MessageAuthenticator, make([]byte, 16)); err != nil {
+			return fmt.Errorf("Packet.Encode: %v", err)
+		}
+	}
 	if err := p.encodeAttributes(); err != nil {
 		return errors.New(fmt.Sprintf("packet encode: %v", err))
 	}
@@ -114,7 +126,18 @@ func (p *Packet) Encode() error {
 		return fmt.Errorf("Packet.Encode: %v", err)
 	}
 
-	p.makeAuthenticator()
+	// Message-Authenticator of Access-Request is signed with its Request Authenticator,
+	// authenticators of other packets are made over the signed attributes
+	accessRequest := p.Type == Code_AccessRequest || p.Type == Code_StatusServer
+	if accessRequest {
+		p.makeAuthenticator()
+	}
+	if err := p.signMessageAuthenticator(pktLen); err != nil {
+		return fmt.Errorf("Packet.Encode: %v", err)
+	}
+	if !accessRequest {
+		p.makeAuthenticator()
+	}
 
 	buffer.Write(p.Authenticator[:])
 	buffer.ReadFrom(&p.attrsBuff)
@@ -127,6 +150,9 @@ func (p *Packet) Encode() error {
 func (p *Packet) makeAuthenticator() error {
 	switch p.Type {
 	case Code_AccessRequest, Code_StatusServer:
+		if p.KeepAuthenticator && p.Authenticator != [16]byte{} {
+			break
+		}
 		if err := p.MakeAccessRequestAuthenticator(); err != nil {
 			return fmt.Errorf("MakeAccessRequestAuthenticator: %v", err)
 		}
@@ -152,7 +178,7 @@ func (p *Packet) makeAuthenticator() error {
 
 func (p *Packet) Decode() (err error) {
 
-	if len(p.Wire) < 3 {
+	if len(p.Wire) < 20 {
 		err = fmt.Errorf("too short packet")
 		return
 	}
@@ -165,6 +191,10 @@ func (p *Packet) Decode() (err error) {
 		err = fmt.Errorf("radius: invalid packet length")
 		return
 	}
+	if len(p.Wire) < int(p.length) {
+		err = fmt.Errorf("radius: packet is shorter than its length %d", p.length)
+		return
+	}
 	n := copy(p.Authenticator[:], p.Wire[4:20])
 	p.lengthDecoded += uint16(n)
 
@@ -173,7 +203,10 @@ func (p *Packet) Decode() (err error) {
 	lengthOfAttrBuf := p.attrsBuff.Len()
 	attrsBuff := p.attrsBuff.Bytes()
 
-	for lengthOfAttrBuf > 0 {
+	// attributes end at the length and octets past it are padding, rfc 2865 3. Attribute crossing
+	// the length is decoded from the datagram as some NAS send wrong length, see DecodeLengthNotMatch
+	attrsLength := int(p.length) - 20
+	for lengthOfAttrBuf > 0 && p.attrsBuff.Len()-lengthOfAttrBuf < attrsLength {
 		if lengthOfAttrBuf < 2 {
 			err = fmt.Errorf("attribute must be at least 2 bytes long, but it's length is %d\n", lengthOfAttrBuf)
 			return
@@ -212,6 +245,11 @@ func (p *Packet) Decode() (err error) {
 		attrsBuff = attrsBuff[attrLength:]
 		lengthOfAttrBuf = len(attrsBuff)
 	}
+	if decoded := p.attrsBuff.Len() - lengthOfAttrBuf; decoded > attrsLength {
+		attrsLength = decoded
+	}
+	p.attrsBuff.Truncate(attrsLength)
+	p.Wire = p.Wire[:20+attrsLength]
 	return
 }
 
@@ -232,6 +270,17 @@ func (p *Packet) AddAttrs(attrs ...*Attribute) {
 	}
 }
 
+// DelAttrs removes all attributes of the type
+func (p *Packet) DelAttrs(t AttributeType) {
+	attrs := p.Attributes[:0]
+	for _, a := range p.Attributes {
+		if a.Type != t {
+			attrs = append(attrs, a)
+		}
+	}
+	p.Attributes = attrs
+}
+
 func (p *Packet) AddAttribute(t AttributeType, value interface{}) (err error) {
 	var attr *Attribute
 	if attr, err = NewAttribute(t); err != nil {
@@ -248,23 +297,22 @@ func (p Packet) LengthDecoded() uint16 {
 	return p.lengthDecoded
 }
 
+// StringMultiLine prints packet with values hidden by DefaultRedactionPolicy
 func (p *Packet) StringMultiLine() string {
+	r := DefaultRedactionPolicy
 	str := fmt.Sprintf("######## PACKET ######## \nType: %s \nId: %d \nAuthentificator: %x\nSecret: %s\n",
-		p.Type, p.Identifier, string(p.Authenticator[:]), p.Secret)
+		p.Type, p.Identifier, p.Authenticator[:], r.secretString(p))
 
 	if len(p.Attributes) > 0 {
 		str += "Attributes:"
 		for _, a := range p.Attributes {
-			if a.Type == Attr_UserPassword {
-				str += fmt.Sprintf("\n\tType: %s Value: %x", a.Type, a.Value)
-			} else {
-				str += fmt.Sprintf("\n\tType: %s Value: %+v", a.Type, a.Value)
-			}
+			str += fmt.Sprintf("\n\t%s: %s", a.Type.label(), r.attrString(a))
 
 			if a.Type == Attr_VendorSpecific {
+				vendorId, _ := a.VendorId()
 				str += fmt.Sprintf("\n\t\t  VSA:")
 				for _, pair := range a.Pairs {
-					str += fmt.Sprintf("VendorId: %d VendorType: %d Value: %q", a.Value, pair.VendorType, pair.Value)
+					str += fmt.Sprintf(" VendorId: %d %s", vendorId, r.vsaString(vendorId, pair))
 				}
 			}
 
@@ -275,23 +323,23 @@ func (p *Packet) StringMultiLine() string {
 	return str
 
 }
+
+// String prints packet with values hidden by DefaultRedactionPolicy, use slog for structured logs
 func (p *Packet) String() string {
+	r := DefaultRedactionPolicy
 	str := fmt.Sprintf("PACKET Type: %s, Id: %d, Authentificator: %x, Secret: %s ",
-		p.Type, p.Identifier, string(p.Authenticator[:]), p.Secret)
+		p.Type, p.Identifier, p.Authenticator[:], r.secretString(p))
 
 	if len(p.Attributes) > 0 {
 		str += "Attributes: "
 		for _, a := range p.Attributes {
-			if a.Type == Attr_UserPassword {
-				str += fmt.Sprintf("| %s : %x ", a.Type, a.Value)
-			} else {
-				str += fmt.Sprintf("| %s : %+v ", a.Type, a.Value)
-			}
+			str += fmt.Sprintf("| %s: %s ", a.Type.label(), r.attrString(a))
 
 			if a.Type == Attr_VendorSpecific {
+				vendorId, _ := a.VendorId()
 				str += fmt.Sprintf(" VSA: ")
 				for _, pair := range a.Pairs {
-					str += fmt.Sprintf("VId: %d VType: %d Val: %q ", a.Value, pair.VendorType, pair.Value)
+					str += fmt.Sprintf("VId: %d %s ", vendorId, r.vsaString(vendorId, pair))
 				}
 			}
 		}
@@ -360,6 +408,19 @@ func (p *Packet) CheckAccountingRequestAuthenticator(secret []byte) (res bool, e
 	return compareTwoSlices(p.Authenticator[:], sum[:]), nil
 }
 
+// CheckResponseAuthenticator checks authenticator of decoded reply, RequestAuthenticator must be set
+func (p *Packet) CheckResponseAuthenticator(secret []byte) (res bool, err error) {
+	if len(p.Wire) < int(p.length) || p.length < MinPacketLength {
+		return false, errors.New("packet is not decoded")
+	}
+	hash := md5.New()
+	hash.Write(p.Wire[:4])
+	hash.Write(p.RequestAuthenticator[:])
+	hash.Write(p.Wire[MinPacketLength:p.length])
+	hash.Write(secret)
+	return bytes.Equal(hash.Sum(nil), p.Authenticator[:]), nil
+}
+
 /*
 
 Response Authenticator
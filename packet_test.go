@@ -3,6 +3,7 @@ package radius
 import (
 	"fmt"
 	"net"
+	"strings"
 	"testing"
 )
 
@@ -152,3 +153,99 @@ func TestPacket_MakeAccountingResponseAuthenticator(t *testing.T) {
 	//todo проверить правильно лы мы на самом деле создали аутентификатор))))))
 
 }
+
+func TestPacket_StringEnumValues(t *testing.T) {
+	p := NewPacket(Code_AccessAccept, []byte("ctrhtn"))
+	p.AddAttribute(Attr_ServiceType, ServiceType_FramedUser)
+	p.AddAttribute(Attr_FramedProtocol, uint32(1))
+	tunnelType, _ := NewAttribute(Attr_TunnelType)
+	tunnelType.Tag = 1
+	tunnelType.Value = TunnelType_VLAN
+	p.AddAttr(tunnelType)
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	reply := &Packet{Wire: p.Wire}
+	if err := reply.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := reply.Attr(Attr_ServiceType).Value.(ServiceType); !ok || v != ServiceType_FramedUser {
+		t.Errorf("Expected %s got %v", ServiceType_FramedUser, reply.Attr(Attr_ServiceType).Value)
+	}
+	var protocol uint32
+	if err := reply.Attr(Attr_FramedProtocol).ValueUint32(&protocol); err != nil || protocol != uint32(FramedProtocol_PPP) {
+		t.Errorf("Expected 1 got %d (%v)", protocol, err)
+	}
+	if a := reply.Attr(Attr_TunnelType); a.Tag != 1 || a.Value != TunnelType_VLAN {
+		t.Errorf("Expected tag 1 and %s got %d and %v", TunnelType_VLAN, a.Tag, a.Value)
+	}
+
+	str := reply.String()
+	for _, s := range []string{"Service-Type: Framed-User(2)", "Framed-Protocol: PPP(1)", "Tunnel-Type: VLAN(13)"} {
+		if !strings.Contains(str, s) {
+			t.Errorf("Expected %q in %s", s, str)
+		}
+	}
+}
+
+func TestPacket_DecodeTruncated(t *testing.T) {
+	for _, wire := range [][]byte{
+		{1, 2, 0},
+		{1, 2, 0, 40, 0, 0},
+		append([]byte{1, 2, 0, 40}, make([]byte, 30)...),
+	} {
+		p := &Packet{Wire: wire}
+		if err := p.Decode(); err == nil {
+			t.Errorf("%x: Expected error of truncated packet", wire)
+		}
+	}
+
+	secret := []byte("ctrhtn")
+	request := NewPacket(Code_AccountingRequest, secret)
+	request.AddAttribute(Attr_UserName, "bob")
+	if err := request.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	// padding past the length is ignored
+	p := &Packet{Wire: append(append([]byte{}, request.Wire...), 0xff, 0xff, 1)}
+	if err := p.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Attributes) != 1 || len(p.Wire) != len(request.Wire) {
+		t.Errorf("Expected User-Name only of %d bytes got %v of %d bytes", len(request.Wire), p.Attributes, len(p.Wire))
+	}
+	if ok, err := p.CheckAccountingRequestAuthenticator(secret); err != nil || !ok {
+		t.Errorf("Expected valid authenticator of padded packet got %v (%v)", ok, err)
+	}
+}
+
+func TestPacket_EncodeAccessRequestAuthenticator(t *testing.T) {
+	secret := []byte("ctrhtn")
+	p := NewPacket(Code_AccessRequest, secret)
+	p.SignMessageAuthenticator = true
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	first := p.Authenticator
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if p.Authenticator == first {
+		t.Error("Expected new Request Authenticator of every Encode")
+	}
+	decoded := &Packet{Wire: p.Wire}
+	decoded.Decode()
+	if ok, err := decoded.CheckMessageAuthenticator(secret); err != nil || !ok {
+		t.Errorf("Expected Message-Authenticator signed with new authenticator got %v %v", ok, err)
+	}
+
+	p.KeepAuthenticator = true
+	kept := p.Authenticator
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if p.Authenticator != kept {
+		t.Errorf("Expected kept authenticator %x got %x", kept, p.Authenticator)
+	}
+}
@@ -0,0 +1,179 @@
+package pcap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+var _ radius.Tap = (*Writer)(nil)
+
+func TestWriter_ReadPackets(t *testing.T) {
+	secret := []byte("ctrhtn")
+	nas := &net.UDPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000}
+	server := &net.UDPAddr{IP: net.ParseIP("10.0.0.2"), Port: 1812}
+	start := time.Date(2024, 3, 1, 12, 0, 0, 5000, time.UTC)
+
+	req := radius.NewPacket(radius.Code_AccessRequest, secret)
+	req.Identifier = 9
+	req.AddAttribute(radius.Attr_UserName, "bob")
+	req.AddAttribute(radius.Attr_NASIdentifier, "nas")
+	if err := req.SetUserPassword("hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	resp := radius.NewPacket(radius.Code_AccessAccept, secret)
+	resp.Identifier = 9
+	resp.RequestAuthenticator = req.Authenticator
+	resp.AddAttribute(radius.Attr_SessionTimeout, uint32(60))
+	if err := resp.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	acct := radius.NewPacket(radius.Code_AccountingRequest, secret)
+	acct.Identifier = 9
+	acct.AddAttribute(radius.Attr_AcctSessionId, "s1")
+	if err := acct.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	acctServer := &net.UDPAddr{IP: net.ParseIP("2001:db8::2"), Port: 1813}
+	acctNAS := &net.UDPAddr{IP: net.ParseIP("2001:db8::1"), Port: 40001}
+
+	var b bytes.Buffer
+	w, err := NewWriter(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.WriteDatagram(start, nas, server, req.Wire)
+	w.WriteDatagram(start.Add(time.Second), nas, server, req.Wire)
+	w.WriteDatagram(start.Add(2*time.Second), server, nas, resp.Wire)
+	w.WriteDatagram(start, nas, &net.UDPAddr{IP: server.IP, Port: 53}, []byte("not radius"))
+	w.WriteDatagram(start.Add(3*time.Second), acctNAS, acctServer, acct.Wire)
+
+	packets, err := ReadPackets(bytes.NewReader(b.Bytes()), Options{Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 4 {
+		t.Fatalf("Expected 4 packets got %d", len(packets))
+	}
+	if p := packets[0]; !p.Time.Equal(start) || p.Src.String() != nas.String() || p.Dst.String() != server.String() {
+		t.Errorf("Expected %s %s -> %s got %s %s -> %s", start, nas, server, p.Time, p.Src, p.Dst)
+	}
+	if p := packets[0]; p.Err != nil || p.Password != "hunter2" || p.VerifyErr != ErrNotVerified {
+		t.Errorf("Expected decrypted unverified Access-Request got %v %q %v", p.Err, p.Password, p.VerifyErr)
+	}
+	if p := packets[3]; p.Src.String() != acctNAS.String() || p.VerifyErr != nil {
+		t.Errorf("Expected verified Accounting-Request from %s got %s %v", acctNAS, p.Src, p.VerifyErr)
+	}
+
+	exchanges := Match(packets)
+	if len(exchanges) != 2 {
+		t.Fatalf("Expected 2 exchanges got %d", len(exchanges))
+	}
+	e := exchanges[0]
+	if e.Response != packets[2] || len(e.Retransmissions) != 1 || e.Latency() != 2*time.Second {
+		t.Errorf("Expected answered retransmitted request got %+v", e)
+	}
+	if e.Response.VerifyErr != nil {
+		t.Errorf("Expected verified response got %v", e.Response.VerifyErr)
+	}
+	if exchanges[1].Response != nil {
+		t.Errorf("Expected unanswered Accounting-Request got %+v", exchanges[1].Response)
+	}
+
+	// wrong secret fails response verification
+	packets, _ = ReadPackets(bytes.NewReader(b.Bytes()), Options{Secret: []byte("wrong")})
+	Match(packets)
+	if packets[2].VerifyErr == nil || packets[2].VerifyErr == ErrNotVerified {
+		t.Errorf("Expected bad Response Authenticator got %v", packets[2].VerifyErr)
+	}
+}
+
+func TestReader_PcapNG(t *testing.T) {
+	p := radius.NewPacket(radius.Code_AccountingRequest, []byte("ctrhtn"))
+	p.AddAttribute(radius.Attr_AcctSessionId, "s1")
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	// ethernet with 802.1Q tag, IPv4 and UDP
+	udp := binary.BigEndian.AppendUint16(nil, 40000)
+	udp = binary.BigEndian.AppendUint16(udp, 1813)
+	udp = binary.BigEndian.AppendUint16(udp, uint16(8+len(p.Wire)))
+	udp = append(udp, 0, 0)
+	udp = append(udp, p.Wire...)
+	ip := []byte{0x45, 0, 0, 0, 0, 0, 0x40, 0, 64, 17, 0, 0, 192, 0, 2, 1, 192, 0, 2, 2}
+	binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(udp)))
+	frame := append(make([]byte, 12), 0x81, 0x00, 0, 100, 0x08, 0x00)
+	frame = append(append(frame, ip...), udp...)
+
+	block := func(blockType uint32, body []byte) []byte {
+		for len(body)%4 != 0 {
+			body = append(body, 0)
+		}
+		b := binary.BigEndian.AppendUint32(nil, blockType)
+		b = binary.BigEndian.AppendUint32(b, uint32(12+len(body)))
+		b = append(b, body...)
+		return binary.BigEndian.AppendUint32(b, uint32(12+len(body)))
+	}
+	var file []byte
+	file = append(file, block(blockSectionHeader, []byte{0x1a, 0x2b, 0x3c, 0x4d, 0, 1, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})...)
+	// nanosecond resolution option
+	file = append(file, block(blockInterface, []byte{0, 1, 0, 0, 0, 0, 0xff, 0xff, 0, 9, 0, 1, 9, 0, 0, 0, 0, 0, 0, 0})...)
+	units := uint64(1700000000123456789)
+	epb := binary.BigEndian.AppendUint32(nil, 0)
+	epb = binary.BigEndian.AppendUint32(epb, uint32(units>>32))
+	epb = binary.BigEndian.AppendUint32(epb, uint32(units))
+	epb = binary.BigEndian.AppendUint32(epb, uint32(len(frame)))
+	epb = binary.BigEndian.AppendUint32(epb, uint32(len(frame)))
+	file = append(file, block(blockEnhancedPacket, append(epb, frame...))...)
+	// unknown block is skipped
+	file = append(file, block(0x0bad, []byte{1, 2, 3})...)
+
+	packets, err := ReadPackets(bytes.NewReader(file), Options{Secret: []byte("ctrhtn")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 1 {
+		t.Fatalf("Expected 1 packet got %d", len(packets))
+	}
+	got := packets[0]
+	if want := time.Unix(0, int64(units)).UTC(); !got.Time.Equal(want) {
+		t.Errorf("Expected time %s got %s", want, got.Time)
+	}
+	if got.Src.String() != "192.0.2.1:40000" || got.Dst.String() != "192.0.2.2:1813" {
+		t.Errorf("Expected 192.0.2.1:40000 -> 192.0.2.2:1813 got %s -> %s", got.Src, got.Dst)
+	}
+	if got.Err != nil || got.VerifyErr != nil || got.Packet.Attr(radius.Attr_AcctSessionId) == nil {
+		t.Errorf("Expected verified Accounting-Request got %v %v %s", got.Err, got.VerifyErr, got.Packet)
+	}
+}
+
+func TestReadPackets_Truncated(t *testing.T) {
+	nas := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 40000}
+	server := &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 1812}
+	var b bytes.Buffer
+	w, err := NewWriter(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// cut by snaplen: length field says 40 bytes
+	w.WriteDatagram(time.Now(), nas, server, []byte{byte(radius.Code_AccessRequest), 1, 0, 40, 0, 0})
+
+	packets, err := ReadPackets(bytes.NewReader(b.Bytes()), Options{Secret: []byte("ctrhtn")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packets) != 1 || packets[0].Err == nil {
+		t.Fatalf("Expected 1 packet with decode error got %+v", packets)
+	}
+	if exchanges := Match(packets); len(exchanges) != 0 {
+		t.Errorf("Expected no exchanges of malformed packet got %d", len(exchanges))
+	}
+}
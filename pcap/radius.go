@@ -0,0 +1,218 @@
+package pcap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	radius "github.com/superlocrian/lib-radius"
+)
+
+// DefaultPorts are RADIUS authentication, accounting and dynamic authorization ports, old ones included
+var DefaultPorts = []int{1812, 1813, 1645, 1646, 3799}
+
+// ErrNotVerified is VerifyErr of packet which authenticators can't be checked
+var ErrNotVerified = errors.New("pcap: packet can't be verified")
+
+// Options of reading RADIUS packets from capture
+type Options struct {
+	// Ports are UDP ports of RADIUS traffic, source or destination, DefaultPorts if empty
+	Ports []int
+	// Secret is shared secret for authenticators and User-Password, nothing is verified if empty
+	Secret []byte
+}
+
+// Packet is RADIUS packet of captured datagram
+type Packet struct {
+	Time time.Time
+	Src  *net.UDPAddr
+	Dst  *net.UDPAddr
+	// Packet is decoded as far as it goes when Err is set
+	Packet *radius.Packet
+	// Err is error of decoding
+	Err error
+	/*
+		VerifyErr is nil if authenticators of packet are valid for the secret: Request Authenticator
+		of accounting and dynamic authorization requests, Response Authenticator of matched
+		responses and Message-Authenticator of any packet. It's ErrNotVerified if there is nothing
+		to check (Access-Request without Message-Authenticator, unmatched response, no secret)
+	*/
+	VerifyErr error
+	// Password is decrypted User-Password of Access-Request
+	Password string
+}
+
+// Exchange is request and its response, one of them is nil if not captured
+type Exchange struct {
+	Request  *Packet
+	Response *Packet
+	// Retransmissions are later copies of request with the same Identifier and Authenticator
+	Retransmissions []*Packet
+}
+
+// Latency is time between request and response, 0 if one of them is missing
+func (e *Exchange) Latency() time.Duration {
+	if e.Request == nil || e.Response == nil {
+		return 0
+	}
+	return e.Response.Time.Sub(e.Request.Time)
+}
+
+// ReadFile reads RADIUS packets of capture file
+func ReadFile(name string, opt Options) ([]*Packet, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadPackets(f, opt)
+}
+
+// ReadPackets reads RADIUS packets of capture, requests are verified and User-Password
+// is decrypted with the secret, responses are verified by Match
+func ReadPackets(r io.Reader, opt Options) ([]*Packet, error) {
+	pr, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	ports := opt.Ports
+	if len(ports) == 0 {
+		ports = DefaultPorts
+	}
+	isRADIUS := func(d *Datagram) bool {
+		for _, port := range ports {
+			if d.Src.Port == port || d.Dst.Port == port {
+				return true
+			}
+		}
+		return false
+	}
+
+	var packets []*Packet
+	for {
+		d, err := pr.ReadDatagram()
+		if err == io.EOF {
+			return packets, nil
+		}
+		if err != nil {
+			return packets, err
+		}
+		if !isRADIUS(d) {
+			continue
+		}
+		p := &Packet{
+			Time:      d.Time,
+			Src:       d.Src,
+			Dst:       d.Dst,
+			Packet:    &radius.Packet{Wire: d.Payload, Secret: opt.Secret},
+			VerifyErr: ErrNotVerified,
+		}
+		if p.Err = p.Packet.Decode(); p.Err == nil && isRequest(p.Packet.Type) {
+			p.verifyRequest()
+		}
+		packets = append(packets, p)
+	}
+}
+
+func (p *Packet) verifyRequest() {
+	rp := p.Packet
+	if len(rp.Secret) == 0 {
+		return
+	}
+	if rp.Type == radius.Code_AccessRequest && rp.Attr(radius.Attr_UserPassword) != nil {
+		p.Password, _ = rp.UserPassword()
+	}
+
+	p.VerifyErr = p.checkMessageAuthenticator()
+	switch rp.Type {
+	case radius.Code_AccountingRequest, radius.Code_CoARequest, radius.Code_DisconnectRequest:
+		if ok, err := rp.CheckAccountingRequestAuthenticator(rp.Secret); err != nil || !ok {
+			p.VerifyErr = errors.New("pcap: bad Request Authenticator")
+		} else if p.VerifyErr == ErrNotVerified {
+			p.VerifyErr = nil
+		}
+	}
+}
+
+func (p *Packet) verifyResponse(request *Packet) {
+	rp := p.Packet
+	if p.Err != nil || len(rp.Secret) == 0 {
+		return
+	}
+	rp.RequestAuthenticator = request.Packet.Authenticator
+	if p.VerifyErr = p.checkMessageAuthenticator(); p.VerifyErr == ErrNotVerified {
+		p.VerifyErr = nil
+	}
+	if ok, err := rp.CheckResponseAuthenticator(rp.Secret); err != nil || !ok {
+		p.VerifyErr = errors.New("pcap: bad Response Authenticator")
+	}
+}
+
+// checkMessageAuthenticator is ErrNotVerified if packet has no Message-Authenticator
+func (p *Packet) checkMessageAuthenticator() error {
+	if p.Packet.Attr(radius.Attr_MessageAuthenticator) == nil {
+		return ErrNotVerified
+	}
+	if ok, err := p.Packet.CheckMessageAuthenticator(p.Packet.Secret); err != nil {
+		return fmt.Errorf("pcap: %v", err)
+	} else if !ok {
+		return errors.New("pcap: bad Message-Authenticator")
+	}
+	return nil
+}
+
+type exchangeKey struct {
+	client, server string
+	identifier     byte
+}
+
+/*
+Match pairs requests with responses by client and server addresses and Identifier, in order
+of capture. Copies of pending request are its retransmissions, request with new Authenticator
+replaces pending one which is left unanswered. Responses are verified with Request Authenticator
+of their request. Packets which failed to decode are skipped.
+*/
+func Match(packets []*Packet) []*Exchange {
+	var exchanges []*Exchange
+	pending := make(map[exchangeKey]*Exchange)
+	for _, p := range packets {
+		if p.Err != nil {
+			continue
+		}
+		if isRequest(p.Packet.Type) {
+			key := exchangeKey{p.Src.String(), p.Dst.String(), p.Packet.Identifier}
+			if e, ok := pending[key]; ok && e.Request.Packet.Authenticator == p.Packet.Authenticator &&
+				bytes.Equal(e.Request.Packet.Wire, p.Packet.Wire) {
+				e.Retransmissions = append(e.Retransmissions, p)
+				continue
+			}
+			e := &Exchange{Request: p}
+			pending[key] = e
+			exchanges = append(exchanges, e)
+			continue
+		}
+		key := exchangeKey{p.Dst.String(), p.Src.String(), p.Packet.Identifier}
+		e, ok := pending[key]
+		if !ok {
+			exchanges = append(exchanges, &Exchange{Response: p})
+			continue
+		}
+		delete(pending, key)
+		e.Response = p
+		p.verifyResponse(e.Request)
+	}
+	return exchanges
+}
+
+func isRequest(code radius.PacketType) bool {
+	switch code {
+	case radius.Code_AccessRequest, radius.Code_AccountingRequest, radius.Code_StatusServer,
+		radius.Code_DisconnectRequest, radius.Code_CoARequest:
+		return true
+	}
+	return false
+}
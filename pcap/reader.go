@@ -0,0 +1,410 @@
+/*
+Package pcap reads RADIUS traffic from classic pcap and pcapng capture files
+and writes datagrams seen by radius.Server to pcap files.
+
+Frames of Ethernet (with 802.1Q tags), raw IP, BSD loopback and Linux cooked
+link types are understood, fragmented IP datagrams are skipped.
+*/
+package pcap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"time"
+)
+
+// link types of tcpdump.org/linktypes.html
+const (
+	LinkType_Null      uint32 = 0
+	LinkType_Ethernet  uint32 = 1
+	LinkType_Raw       uint32 = 101
+	LinkType_Loop      uint32 = 108
+	LinkType_LinuxSLL  uint32 = 113
+	LinkType_IPv4      uint32 = 228
+	LinkType_IPv6      uint32 = 229
+	LinkType_LinuxSLL2 uint32 = 276
+)
+
+const (
+	magicMicroseconds = 0xa1b2c3d4
+	magicNanoseconds  = 0xa1b23c4d
+
+	blockSectionHeader  = 0x0a0d0d0a
+	blockInterface      = 1
+	blockPacket         = 2
+	blockSimplePacket   = 3
+	blockEnhancedPacket = 6
+	byteOrderMagic      = 0x1a2b3c4d
+
+	optionTimestampResolution = 9
+
+	// maxBlockLength guards allocations of corrupted captures
+	maxBlockLength = 16 << 20
+)
+
+// Datagram is UDP datagram of captured frame
+type Datagram struct {
+	Time    time.Time
+	Src     *net.UDPAddr
+	Dst     *net.UDPAddr
+	Payload []byte
+}
+
+type ngInterface struct {
+	linkType uint32
+	// units of timestamp per second
+	resolution uint64
+}
+
+// Reader reads UDP datagrams of pcap or pcapng capture, format is detected by magic
+type Reader struct {
+	r     *bufio.Reader
+	order binary.ByteOrder
+
+	ng bool
+	// classic pcap
+	linkType uint32
+	nano     bool
+	// pcapng interfaces of current section
+	interfaces []ngInterface
+}
+
+// NewReader reads file header of capture
+func NewReader(r io.Reader) (*Reader, error) {
+	pr := &Reader{r: bufio.NewReader(r)}
+	head, err := pr.r.Peek(4)
+	if err != nil {
+		return nil, fmt.Errorf("pcap: read file header: %v", err)
+	}
+	if binary.LittleEndian.Uint32(head) == blockSectionHeader {
+		pr.ng = true
+		return pr, nil
+	}
+
+	var header [24]byte
+	if _, err := io.ReadFull(pr.r, header[:]); err != nil {
+		return nil, fmt.Errorf("pcap: read file header: %v", err)
+	}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch order.Uint32(header[:4]) {
+		case magicMicroseconds:
+			pr.order = order
+		case magicNanoseconds:
+			pr.order, pr.nano = order, true
+		}
+		if pr.order != nil {
+			break
+		}
+	}
+	if pr.order == nil {
+		return nil, fmt.Errorf("pcap: unknown file magic %x", header[:4])
+	}
+	// upper bits of link type are FCS length and flags
+	pr.linkType = pr.order.Uint32(header[20:24]) & 0xffff
+	return pr, nil
+}
+
+// ReadDatagram returns next UDP datagram of capture skipping other frames, io.EOF at the end
+func (r *Reader) ReadDatagram() (*Datagram, error) {
+	for {
+		t, linkType, frame, err := r.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		if d := parseFrame(linkType, frame); d != nil {
+			d.Time = t
+			return d, nil
+		}
+	}
+}
+
+func (r *Reader) readFrame() (t time.Time, linkType uint32, frame []byte, err error) {
+	if r.ng {
+		return r.readBlocks()
+	}
+	var header [16]byte
+	if _, err = io.ReadFull(r.r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errors.New("pcap: truncated record header")
+		}
+		return
+	}
+	length := r.order.Uint32(header[8:12])
+	if length > maxBlockLength {
+		err = fmt.Errorf("pcap: invalid record length %d", length)
+		return
+	}
+	frame = make([]byte, length)
+	if _, err = io.ReadFull(r.r, frame); err != nil {
+		err = fmt.Errorf("pcap: truncated record: %v", err)
+		return
+	}
+	fraction := time.Duration(r.order.Uint32(header[4:8]))
+	if !r.nano {
+		fraction *= time.Microsecond
+	}
+	t = time.Unix(int64(r.order.Uint32(header[:4])), int64(fraction)).UTC()
+	return t, r.linkType, frame, nil
+}
+
+// readBlocks reads pcapng blocks until block with a frame
+func (r *Reader) readBlocks() (t time.Time, linkType uint32, frame []byte, err error) {
+	for {
+		var blockType uint32
+		var body []byte
+		if blockType, body, err = r.readBlock(); err != nil {
+			return
+		}
+		switch blockType {
+		case blockInterface:
+			if len(body) < 8 {
+				err = errors.New("pcap: truncated interface description block")
+				return
+			}
+			ifc := ngInterface{linkType: uint32(r.order.Uint16(body[:2])), resolution: 1e6}
+			r.readOptions(body[8:], func(code uint16, value []byte) {
+				if code == optionTimestampResolution && len(value) == 1 {
+					ifc.resolution = resolution(value[0])
+				}
+			})
+			r.interfaces = append(r.interfaces, ifc)
+			continue
+		case blockEnhancedPacket, blockPacket:
+			if len(body) < 20 {
+				err = errors.New("pcap: truncated packet block")
+				return
+			}
+			id := r.order.Uint32(body[:4])
+			if blockType == blockPacket {
+				id = uint32(r.order.Uint16(body[:2]))
+			}
+			if int(id) >= len(r.interfaces) {
+				err = fmt.Errorf("pcap: packet of unknown interface %d", id)
+				return
+			}
+			ifc := r.interfaces[id]
+			length := r.order.Uint32(body[12:16])
+			if int(length) > len(body)-20 {
+				err = fmt.Errorf("pcap: invalid captured length %d", length)
+				return
+			}
+			units := uint64(r.order.Uint32(body[4:8]))<<32 | uint64(r.order.Uint32(body[8:12]))
+			t = time.Unix(int64(units/ifc.resolution),
+				int64((units%ifc.resolution)*1e9/ifc.resolution)).UTC()
+			return t, ifc.linkType, body[20 : 20+length], nil
+		case blockSimplePacket:
+			if len(body) < 4 || len(r.interfaces) == 0 {
+				err = errors.New("pcap: invalid simple packet block")
+				return
+			}
+			length := r.order.Uint32(body[:4])
+			if int(length) > len(body)-4 {
+				length = uint32(len(body) - 4)
+			}
+			// simple packet has no timestamp
+			return time.Time{}, r.interfaces[0].linkType, body[4 : 4+length], nil
+		}
+	}
+}
+
+// readBlock reads pcapng block, section header sets byte order of following blocks
+func (r *Reader) readBlock() (blockType uint32, body []byte, err error) {
+	var header [8]byte
+	if _, err = io.ReadFull(r.r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errors.New("pcap: truncated block header")
+		}
+		return
+	}
+	// section header type is the same in both byte orders
+	if binary.LittleEndian.Uint32(header[:4]) == blockSectionHeader {
+		var magic []byte
+		if magic, err = r.r.Peek(4); err != nil {
+			err = fmt.Errorf("pcap: truncated section header: %v", err)
+			return
+		}
+		switch {
+		case binary.LittleEndian.Uint32(magic) == byteOrderMagic:
+			r.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(magic) == byteOrderMagic:
+			r.order = binary.BigEndian
+		default:
+			err = fmt.Errorf("pcap: unknown byte order magic %x", magic)
+			return
+		}
+		r.interfaces = nil
+	}
+	if r.order == nil {
+		err = errors.New("pcap: block before section header")
+		return
+	}
+	blockType = r.order.Uint32(header[:4])
+	length := r.order.Uint32(header[4:8])
+	if length < 12 || length%4 != 0 || length > maxBlockLength {
+		err = fmt.Errorf("pcap: invalid block length %d", length)
+		return
+	}
+	rest := make([]byte, length-8)
+	if _, err = io.ReadFull(r.r, rest); err != nil {
+		err = fmt.Errorf("pcap: truncated block: %v", err)
+		return
+	}
+	// body is followed by repeated length
+	return blockType, rest[:len(rest)-4], nil
+}
+
+// readOptions calls f for every option of block, options are padded to 4 octets
+func (r *Reader) readOptions(options []byte, f func(code uint16, value []byte)) {
+	for len(options) >= 4 {
+		code := r.order.Uint16(options[:2])
+		length := int(r.order.Uint16(options[2:4]))
+		if code == 0 || 4+length > len(options) {
+			return
+		}
+		f(code, options[4:4+length])
+		if n := 4 + (length+3)/4*4; n < len(options) {
+			options = options[n:]
+		} else {
+			return
+		}
+	}
+}
+
+// resolution is units per second of if_tsresol: power of 10, or power of 2 with high bit set
+func resolution(tsresol byte) uint64 {
+	exp := uint64(tsresol & 0x7f)
+	if tsresol&0x80 != 0 {
+		if exp > 63 {
+			return 1e6
+		}
+		return 1 << exp
+	}
+	if exp > 19 {
+		return 1e6
+	}
+	return uint64(math.Pow10(int(exp)))
+}
+
+// parseFrame finds UDP datagram in frame, nil if it's not one
+func parseFrame(linkType uint32, frame []byte) *Datagram {
+	var etherType uint16
+	switch linkType {
+	case LinkType_Ethernet:
+		if len(frame) < 14 {
+			return nil
+		}
+		etherType, frame = binary.BigEndian.Uint16(frame[12:14]), frame[14:]
+		// 802.1Q and 802.1ad tags
+		for (etherType == 0x8100 || etherType == 0x88a8) && len(frame) >= 4 {
+			etherType, frame = binary.BigEndian.Uint16(frame[2:4]), frame[4:]
+		}
+	case LinkType_Null, LinkType_Loop:
+		if len(frame) < 4 {
+			return nil
+		}
+		// address family of capturing host byte order, its value is small in one of orders
+		family := binary.LittleEndian.Uint32(frame[:4])
+		if linkType == LinkType_Loop || family > 0xffff {
+			family = binary.BigEndian.Uint32(frame[:4])
+		}
+		etherType, frame = 0x86dd, frame[4:]
+		if family == 2 {
+			etherType = 0x0800
+		}
+	case LinkType_LinuxSLL:
+		if len(frame) < 16 {
+			return nil
+		}
+		etherType, frame = binary.BigEndian.Uint16(frame[14:16]), frame[16:]
+	case LinkType_LinuxSLL2:
+		if len(frame) < 20 {
+			return nil
+		}
+		etherType, frame = binary.BigEndian.Uint16(frame[:2]), frame[20:]
+	case LinkType_Raw, LinkType_IPv4, LinkType_IPv6:
+		if len(frame) < 1 {
+			return nil
+		}
+		etherType = 0x0800
+		if frame[0]>>4 == 6 {
+			etherType = 0x86dd
+		}
+	default:
+		return nil
+	}
+
+	switch etherType {
+	case 0x0800:
+		return parseIPv4(frame)
+	case 0x86dd:
+		return parseIPv6(frame)
+	}
+	return nil
+}
+
+func parseIPv4(packet []byte) *Datagram {
+	if len(packet) < 20 || packet[0]>>4 != 4 {
+		return nil
+	}
+	headerLength := int(packet[0]&0x0f) * 4
+	total := int(binary.BigEndian.Uint16(packet[2:4]))
+	if headerLength < 20 || total < headerLength || total > len(packet) {
+		return nil
+	}
+	// more fragments flag or fragment offset
+	if binary.BigEndian.Uint16(packet[6:8])&0x3fff != 0 || packet[9] != 17 {
+		return nil
+	}
+	return parseUDP(net.IP(packet[12:16]), net.IP(packet[16:20]), packet[headerLength:total])
+}
+
+func parseIPv6(packet []byte) *Datagram {
+	if len(packet) < 40 || packet[0]>>4 != 6 {
+		return nil
+	}
+	payloadLength := int(binary.BigEndian.Uint16(packet[4:6]))
+	if 40+payloadLength > len(packet) {
+		return nil
+	}
+	src, dst := net.IP(packet[8:24]), net.IP(packet[24:40])
+	next, payload := packet[6], packet[40:40+payloadLength]
+	for {
+		switch next {
+		case 17:
+			return parseUDP(src, dst, payload)
+		case 0, 43, 60:
+			// hop-by-hop, routing and destination options
+			if len(payload) < 8 {
+				return nil
+			}
+			length := (int(payload[1]) + 1) * 8
+			if length > len(payload) {
+				return nil
+			}
+			next, payload = payload[0], payload[length:]
+		default:
+			// fragments and other protocols
+			return nil
+		}
+	}
+}
+
+func parseUDP(src, dst net.IP, segment []byte) *Datagram {
+	if len(segment) < 8 {
+		return nil
+	}
+	length := int(binary.BigEndian.Uint16(segment[4:6]))
+	if length < 8 || length > len(segment) {
+		return nil
+	}
+	return &Datagram{
+		Src:     &net.UDPAddr{IP: append(net.IP(nil), src...), Port: int(binary.BigEndian.Uint16(segment[:2]))},
+		Dst:     &net.UDPAddr{IP: append(net.IP(nil), dst...), Port: int(binary.BigEndian.Uint16(segment[2:4]))},
+		Payload: segment[8:length],
+	}
+}
@@ -0,0 +1,119 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Writer writes UDP datagrams to classic pcap of raw IP link type, it's safe for concurrent use
+// and implements radius.Tap
+type Writer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter writes file header of capture
+func NewWriter(w io.Writer) (*Writer, error) {
+	var header [24]byte
+	binary.LittleEndian.PutUint32(header[:4], magicMicroseconds)
+	binary.LittleEndian.PutUint16(header[4:6], 2)
+	binary.LittleEndian.PutUint16(header[6:8], 4)
+	binary.LittleEndian.PutUint32(header[16:20], 65535)
+	binary.LittleEndian.PutUint32(header[20:24], LinkType_Raw)
+	if _, err := w.Write(header[:]); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w}, nil
+}
+
+// WriteDatagram writes payload from src to dst with made up IP and UDP headers,
+// IPv6 header is used if any of addresses is not IPv4
+func (w *Writer) WriteDatagram(t time.Time, src, dst *net.UDPAddr, payload []byte) error {
+	if src == nil || dst == nil {
+		return errors.New("pcap: datagram without address")
+	}
+	if len(payload) > 65535-48 {
+		return errors.New("pcap: datagram is too long")
+	}
+	udp := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint16(udp[0:2], uint16(src.Port))
+	binary.BigEndian.PutUint16(udp[2:4], uint16(dst.Port))
+	binary.BigEndian.PutUint16(udp[4:6], uint16(8+len(payload)))
+	udp = append(udp, payload...)
+
+	var frame []byte
+	if src4, dst4 := src.IP.To4(), dst.IP.To4(); (src4 != nil || src.IP == nil) && (dst4 != nil || dst.IP == nil) {
+		frame = make([]byte, 20, 20+len(udp))
+		frame[0] = 0x45
+		binary.BigEndian.PutUint16(frame[2:4], uint16(20+len(udp)))
+		frame[8] = 64
+		frame[9] = 17
+		copy(frame[12:16], ipOrZero(src4, net.IPv4len))
+		copy(frame[16:20], ipOrZero(dst4, net.IPv4len))
+		binary.BigEndian.PutUint16(frame[10:12], checksum(0, frame))
+		binary.BigEndian.PutUint16(udp[6:8], udpChecksum(frame[12:20], udp))
+	} else {
+		frame = make([]byte, 40, 40+len(udp))
+		frame[0] = 0x60
+		binary.BigEndian.PutUint16(frame[4:6], uint16(len(udp)))
+		frame[6] = 17
+		frame[7] = 64
+		copy(frame[8:24], ipOrZero(src.IP.To16(), net.IPv6len))
+		copy(frame[24:40], ipOrZero(dst.IP.To16(), net.IPv6len))
+		binary.BigEndian.PutUint16(udp[6:8], udpChecksum(frame[8:40], udp))
+	}
+	frame = append(frame, udp...)
+
+	var record [16]byte
+	binary.LittleEndian.PutUint32(record[0:4], uint32(t.Unix()))
+	binary.LittleEndian.PutUint32(record[4:8], uint32(t.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(record[8:12], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(record[12:16], uint32(len(frame)))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.w.Write(record[:]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(frame)
+	return err
+}
+
+func ipOrZero(ip net.IP, size int) net.IP {
+	if ip == nil {
+		return make(net.IP, size)
+	}
+	return ip
+}
+
+// udpChecksum is checksum of segment with pseudo header of both addresses
+func udpChecksum(addresses, segment []byte) uint16 {
+	var pseudo [8]byte
+	binary.BigEndian.PutUint32(pseudo[0:4], uint32(len(segment)))
+	pseudo[7] = 17
+	sum := checksum(0, append(append([]byte(nil), addresses...), pseudo[:]...))
+	sum = checksum(^sum, segment)
+	if sum == 0 {
+		return 0xffff
+	}
+	return sum
+}
+
+// checksum is internet checksum of b continued from partial sum
+func checksum(partial uint16, b []byte) uint16 {
+	sum := uint32(partial)
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum > 0xffff {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
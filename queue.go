@@ -0,0 +1,125 @@
+package radius
+
+import (
+	"sync"
+)
+
+// DefaultQueueSize is queue of Server with MaxConcurrency if QueueSize is 0
+const DefaultQueueSize = 1024
+
+// DropPolicy chooses request dropped when Server queue is full
+type DropPolicy int
+
+const (
+	// DropPolicy_Newest drops request arriving to full queue
+	DropPolicy_Newest DropPolicy = iota
+	// DropPolicy_Priority drops newest queued request of other codes to make room for Status-Server
+	// and Access-Request, which are also served first
+	DropPolicy_Priority
+)
+
+// OverloadStats are counters of Server overload protection
+type OverloadStats struct {
+	// Queued is number of requests waiting for a worker
+	Queued int
+	// Dropped is number of requests dropped since start
+	Dropped uint64
+	// DroppedByCode is Dropped by packet code
+	DroppedByCode map[PacketType]uint64
+}
+
+// requestQueue is bounded queue of requests waiting for workers
+type requestQueue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	size   int
+	policy DropPolicy
+	// high is Status-Server and Access-Request of DropPolicy_Priority, low is everything else
+	high, low []*udpResponseWriter
+	closed    bool
+
+	dropped       uint64
+	droppedByCode map[PacketType]uint64
+}
+
+func newRequestQueue(size int, policy DropPolicy) *requestQueue {
+	if size <= 0 {
+		size = DefaultQueueSize
+	}
+	q := &requestQueue{size: size, policy: policy, droppedByCode: make(map[PacketType]uint64)}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func isPriorityCode(code PacketType) bool {
+	return code == Code_AccessRequest || code == Code_StatusServer
+}
+
+// push queues request, dropped is request which didn't fit: w or one of queued
+func (q *requestQueue) push(w *udpResponseWriter) (dropped *udpResponseWriter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	high := q.policy == DropPolicy_Priority && isPriorityCode(w.request.Packet.Type)
+	if len(q.high)+len(q.low) >= q.size {
+		if !high || len(q.low) == 0 {
+			q.countDrop(w)
+			return w
+		}
+		dropped = q.low[len(q.low)-1]
+		q.low = q.low[:len(q.low)-1]
+		q.countDrop(dropped)
+	}
+	if high {
+		q.high = append(q.high, w)
+	} else {
+		q.low = append(q.low, w)
+	}
+	q.cond.Signal()
+	return dropped
+}
+
+func (q *requestQueue) countDrop(w *udpResponseWriter) {
+	q.dropped++
+	q.droppedByCode[w.request.Packet.Type]++
+}
+
+// pop waits for request, nil when queue is closed and empty
+func (q *requestQueue) pop() *udpResponseWriter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.high)+len(q.low) == 0 {
+		if q.closed {
+			return nil
+		}
+		q.cond.Wait()
+	}
+	var w *udpResponseWriter
+	if len(q.high) > 0 {
+		w, q.high = q.high[0], q.high[1:]
+	} else {
+		w, q.low = q.low[0], q.low[1:]
+	}
+	return w
+}
+
+// close makes workers exit when queued requests are served
+func (q *requestQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+func (q *requestQueue) stats() OverloadStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stats := OverloadStats{
+		Queued:        len(q.high) + len(q.low),
+		Dropped:       q.dropped,
+		DroppedByCode: make(map[PacketType]uint64, len(q.droppedByCode)),
+	}
+	for code, n := range q.droppedByCode {
+		stats.DroppedByCode[code] = n
+	}
+	return stats
+}
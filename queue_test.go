@@ -0,0 +1,99 @@
+package radius
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func queued(code PacketType) *udpResponseWriter {
+	return &udpResponseWriter{request: &Request{Packet: &Packet{Type: code}}}
+}
+
+func TestRequestQueue(t *testing.T) {
+	q := newRequestQueue(2, DropPolicy_Newest)
+	acct1, acct2, auth := queued(Code_AccountingRequest), queued(Code_AccountingRequest), queued(Code_AccessRequest)
+	q.push(acct1)
+	q.push(acct2)
+	if dropped := q.push(auth); dropped != auth {
+		t.Errorf("Expected newest request to be dropped got %+v", dropped)
+	}
+	if w := q.pop(); w != acct1 {
+		t.Errorf("Expected first request got %+v", w)
+	}
+
+	q = newRequestQueue(2, DropPolicy_Priority)
+	q.push(acct1)
+	q.push(acct2)
+	if dropped := q.push(auth); dropped != acct2 {
+		t.Errorf("Expected newest accounting request to be dropped got %+v", dropped)
+	}
+	if dropped := q.push(queued(Code_AccountingRequest)); dropped == nil || dropped.request.Packet.Type != Code_AccountingRequest {
+		t.Errorf("Expected accounting request to be dropped got %+v", dropped)
+	}
+	if w := q.pop(); w != auth {
+		t.Errorf("Expected Access-Request first got %+v", w)
+	}
+	stats := q.stats()
+	if stats.Queued != 1 || stats.Dropped != 2 || stats.DroppedByCode[Code_AccountingRequest] != 2 {
+		t.Errorf("Expected 1 queued and 2 dropped accounting requests got %+v", stats)
+	}
+
+	q.close()
+	if w := q.pop(); w != acct1 {
+		t.Errorf("Expected queued request after close got %+v", w)
+	}
+	if w := q.pop(); w != nil {
+		t.Errorf("Expected nil of closed queue got %+v", w)
+	}
+}
+
+func TestServer_MaxConcurrency(t *testing.T) {
+	secret := []byte("ctrhtn")
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	s := &Server{
+		MaxConcurrency: 1,
+		QueueSize:      1,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			started <- struct{}{}
+			<-release
+			w.Write(&Packet{Type: Code_AccountingResponse, Secret: secret})
+		}),
+	}
+	addr, _ := startServer(t, s)
+	conn, err := net.DialUDP("udp4", nil, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for i := 0; i < 3; i++ {
+		request := NewPacket(Code_AccountingRequest, secret)
+		request.Identifier = byte(i)
+		request.Encode()
+		conn.Write(request.Wire)
+		if i == 0 {
+			<-started
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	if stats := s.OverloadStats(); stats.Queued != 1 || stats.Dropped != 1 {
+		t.Errorf("Expected 1 queued and 1 dropped request got %+v", stats)
+	}
+	close(release)
+
+	replies := 0
+	buff := make([]byte, MaxPacketLength)
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	for {
+		if _, err := conn.Read(buff); err != nil {
+			break
+		}
+		replies++
+	}
+	if replies != 2 {
+		t.Errorf("Expected 2 replies got %d", replies)
+	}
+}
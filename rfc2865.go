@@ -1,5 +1,13 @@
 package radius
 
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
 const (
 	//rfc 2865
 	Code_AccessRequest      PacketType = 1
@@ -15,51 +23,130 @@ const (
 
 	MaxPacketLength = 4096
 	MinPacketLength = 20
+)
 
-	//attributes
-	Attr_UserName          AttributeType = 1
-	Attr_UserPassword      AttributeType = 2
-	Attr_CHAPPassword      AttributeType = 3
-	Attr_NASIPAddress      AttributeType = 4
-	Attr_NASPort           AttributeType = 5
-	Attr_ServiceType       AttributeType = 6
-	Attr_FramedProtocol    AttributeType = 7
-	Attr_FramedIPAddress   AttributeType = 8
-	Attr_FramedIPNetmask   AttributeType = 9
-	Attr_FramedRouting     AttributeType = 10
-	Attr_FilterId          AttributeType = 11
-	Attr_FramedMTU         AttributeType = 12
-	Attr_FramedCompression AttributeType = 13
-	Attr_LoginIPHost       AttributeType = 14
-	Attr_LoginService      AttributeType = 15
-	Attr_LoginTCPPort      AttributeType = 16
-	//(unassigned)				AttributeType =  17
-	Attr_ReplyMessage   AttributeType = 18
-	Attr_CallbackNumber AttributeType = 19
-	Attr_CallbackId     AttributeType = 20
-	//(unassigned)				AttributeType =  21
-	Attr_FramedRoute            AttributeType = 22
-	Attr_FramedIPXNetwork       AttributeType = 23
-	Attr_State                  AttributeType = 24
-	Attr_Class                  AttributeType = 25
-	Attr_VendorSpecific         AttributeType = 26
-	Attr_SessionTimeout         AttributeType = 27
-	Attr_IdleTimeout            AttributeType = 28
-	Attr_TerminationAction      AttributeType = 29
-	Attr_CalledStationId        AttributeType = 30
-	Attr_CallingStationId       AttributeType = 31
-	Attr_NASIdentifier          AttributeType = 32
-	Attr_ProxyState             AttributeType = 33
-	Attr_LoginLATService        AttributeType = 34
-	Attr_LoginLATNode           AttributeType = 35
-	Attr_LoginLATGroup          AttributeType = 36
-	Attr_FramedAppleTalkLink    AttributeType = 37
-	Attr_FramedAppleTalkNetwork AttributeType = 38
-	Attr_FramedAppleTalkZone    AttributeType = 39
-	//(reserved for accounting)	AttributeType =  40-59
+// rfc 2865 5.44 table of attributes
+func init() {
+	registerOccurs(
+		[]PacketType{Code_AccessRequest, Code_AccessAccept, Code_AccessReject, Code_AccessChallenge},
+		map[AttributeType][]Occurs{
+			Attr_UserName:               {o01, o01, o0, o0},
+			Attr_UserPassword:           {o01, o0, o0, o0},
+			Attr_CHAPPassword:           {o01, o0, o0, o0},
+			Attr_NASIPAddress:           {o01, o0, o0, o0},
+			Attr_NASPort:                {o01, o0, o0, o0},
+			Attr_ServiceType:            {o01, o01, o0, o0},
+			Attr_FramedProtocol:         {o01, o01, o0, o0},
+			Attr_FramedIPAddress:        {o01, o01, o0, o0},
+			Attr_FramedIPNetmask:        {o01, o01, o0, o0},
+			Attr_FramedRouting:          {o0, o01, o0, o0},
+			Attr_FilterId:               {o0, o0n, o0, o0},
+			Attr_FramedMTU:              {o01, o01, o0, o0},
+			Attr_FramedCompression:      {o0n, o0n, o0, o0},
+			Attr_LoginIPHost:            {o0n, o0n, o0, o0},
+			Attr_LoginService:           {o0, o01, o0, o0},
+			Attr_LoginTCPPort:           {o0, o01, o0, o0},
+			Attr_ReplyMessage:           {o0, o0n, o0n, o0n},
+			Attr_CallbackNumber:         {o01, o01, o0, o0},
+			Attr_CallbackId:             {o0, o01, o0, o0},
+			Attr_FramedRoute:            {o0, o0n, o0, o0},
+			Attr_FramedIPXNetwork:       {o0, o01, o0, o0},
+			Attr_State:                  {o01, o01, o0, o01},
+			Attr_Class:                  {o0, o0n, o0, o0},
+			Attr_VendorSpecific:         {o0n, o0n, o0, o0n},
+			Attr_SessionTimeout:         {o0, o01, o0, o01},
+			Attr_IdleTimeout:            {o0, o01, o0, o01},
+			Attr_TerminationAction:      {o0, o01, o0, o0},
+			Attr_CalledStationId:        {o01, o0, o0, o0},
+			Attr_CallingStationId:       {o01, o0, o0, o0},
+			Attr_NASIdentifier:          {o01, o0, o0, o0},
+			Attr_ProxyState:             {o0n, o0n, o0n, o0n},
+			Attr_LoginLATService:        {o01, o01, o0, o0},
+			Attr_LoginLATNode:           {o01, o01, o0, o0},
+			Attr_LoginLATGroup:          {o01, o01, o0, o0},
+			Attr_FramedAppleTalkLink:    {o0, o01, o0, o0},
+			Attr_FramedAppleTalkNetwork: {o0, o0n, o0, o0},
+			Attr_FramedAppleTalkZone:    {o0, o01, o0, o0},
+			Attr_CHAPChallenge:          {o01, o0, o0, o0},
+			Attr_NASPortType:            {o01, o0, o0, o0},
+			Attr_PortLimit:              {o01, o01, o0, o0},
+			Attr_LoginLATPort:           {o01, o01, o0, o0},
+		})
+}
 
-	Attr_CHAPChallenge AttributeType = 60
-	Attr_NASPortType   AttributeType = 61
-	Attr_PortLimit     AttributeType = 62
-	Attr_LoginLATPort  AttributeType = 63
-)
+/*
+	rfc 2865 5.2 User-Password is padded to 16 octets and xored with
+	MD5(secret + Request Authenticator), every next block with MD5(secret + previous ciphertext)
+*/
+
+// UserPassword decrypts User-Password of Access-Request with Secret and Authenticator of packet
+func (p *Packet) UserPassword() (string, error) {
+	a := p.Attr(Attr_UserPassword)
+	if a == nil {
+		return "", errors.New("packet has no User-Password")
+	}
+	if len(p.Secret) == 0 {
+		return "", errors.New("need secret to decrypt User-Password")
+	}
+	var cipher []byte
+	switch v := a.Value.(type) {
+	case string:
+		cipher = []byte(v)
+	case []byte:
+		cipher = v
+	}
+	if len(cipher) < 16 || len(cipher) > 128 || len(cipher)%16 != 0 {
+		return "", fmt.Errorf("User-Password has invalid size %d", len(cipher))
+	}
+	password := make([]byte, len(cipher))
+	last := p.Authenticator[:]
+	for i := 0; i < len(cipher); i += 16 {
+		block := passwordBlock(p.Secret, last)
+		for j := range block {
+			password[i+j] = cipher[i+j] ^ block[j]
+		}
+		last = cipher[i : i+16]
+	}
+	return string(bytes.TrimRight(password, "\x00")), nil
+}
+
+// SetUserPassword replaces User-Password with password encrypted by Secret and Authenticator,
+// random Authenticator is made if it's not set yet and KeepAuthenticator is set for Encode
+func (p *Packet) SetUserPassword(password string) error {
+	if len(p.Secret) == 0 {
+		return errors.New("need secret to encrypt User-Password")
+	}
+	if len(password) > 128 {
+		return errors.New("User-Password is longer than 128 octets")
+	}
+	if p.Authenticator == [16]byte{} {
+		if _, err := rand.Read(p.Authenticator[:]); err != nil {
+			return err
+		}
+	}
+	p.KeepAuthenticator = true
+	n := (len(password) + 15) / 16 * 16
+	if n == 0 {
+		n = 16
+	}
+	cipher := make([]byte, n)
+	copy(cipher, password)
+	last := p.Authenticator[:]
+	for i := 0; i < n; i += 16 {
+		block := passwordBlock(p.Secret, last)
+		for j := range block {
+			cipher[i+j] ^= block[j]
+		}
+		last = cipher[i : i+16]
+	}
+	p.DelAttrs(Attr_UserPassword)
+	// decoded User-Password keeps the ciphertext as string
+	return p.AddAttribute(Attr_UserPassword, string(cipher))
+}
+
+func passwordBlock(secret, last []byte) []byte {
+	hash := md5.New()
+	hash.Write(secret)
+	hash.Write(last)
+	return hash.Sum(nil)
+}
@@ -0,0 +1,31 @@
+package radius
+
+import "testing"
+
+func TestPacket_UserPassword(t *testing.T) {
+	for _, password := range []string{"", "secret", "exactly16octets!", "longer than sixteen octets of password"} {
+		p := NewPacket(Code_AccessRequest, []byte("ctrhtn"))
+		p.AddAttribute(Attr_UserName, "bob")
+		if err := p.SetUserPassword(password); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Encode(); err != nil {
+			t.Fatal(err)
+		}
+
+		req := &Packet{Wire: p.Wire, Secret: []byte("ctrhtn")}
+		if err := req.Decode(); err != nil {
+			t.Fatal(err)
+		}
+		if cipher := req.Attr(Attr_UserPassword).Wire[2:]; len(cipher)%16 != 0 || string(cipher) == password {
+			t.Errorf("Expected padded ciphertext got %q", cipher)
+		}
+		if got, err := req.UserPassword(); err != nil || got != password {
+			t.Errorf("Expected %q got %q (%v)", password, got, err)
+		}
+		req.Secret = []byte("wrong")
+		if got, _ := req.UserPassword(); got == password && password != "" {
+			t.Errorf("Expected garbage with wrong secret got %q", got)
+		}
+	}
+}
@@ -1,26 +1,62 @@
 package radius
 
-const (
-	Attr_AcctStatusType     AttributeType = 40
-	Attr_AcctDelayTime      AttributeType = 41
-	Attr_AcctInputOctets    AttributeType = 42
-	Attr_AcctOutputOctets   AttributeType = 43
-	Attr_AcctSessionId      AttributeType = 44
-	Attr_AcctAuthentic      AttributeType = 45
-	Attr_AcctSessionTime    AttributeType = 46
-	Attr_AcctInputPackets   AttributeType = 47
-	Attr_AcctOutputPackets  AttributeType = 48
-	Attr_AcctTerminateCause AttributeType = 49
-	Attr_AcctMultiSessionId AttributeType = 50
-	Attr_AcctLinkCount      AttributeType = 51
-
-	//values
-	Attr_AcctStatusType_Value_Start         AttributeValue = 1
-	Attr_AcctStatusType_Value_Stop          AttributeValue = 2
-	Attr_AcctStatusType_Value_InterimUpdate AttributeValue = 3
-	Attr_AcctStatusType_Value_AccountingOn  AttributeValue = 7
-	Attr_AcctStatusType_Value_AccountingOff AttributeValue = 8
-
-//9-14   Reserved for Tunnel Accounting
-//15     Reserved for Failed
-)
+// rfc 2866 5.13 table of attributes, Accounting-Response carries only Proxy-State and vendor attributes
+func init() {
+	registerOccurs(
+		[]PacketType{Code_AccountingRequest, Code_AccountingResponse},
+		map[AttributeType][]Occurs{
+			Attr_UserName:               {o01, o0},
+			Attr_UserPassword:           {o0, o0},
+			Attr_CHAPPassword:           {o0, o0},
+			Attr_NASIPAddress:           {o01, o0},
+			Attr_NASPort:                {o01, o0},
+			Attr_ServiceType:            {o01, o0},
+			Attr_FramedProtocol:         {o01, o0},
+			Attr_FramedIPAddress:        {o01, o0},
+			Attr_FramedIPNetmask:        {o01, o0},
+			Attr_FramedRouting:          {o01, o0},
+			Attr_FilterId:               {o0n, o0},
+			Attr_FramedMTU:              {o01, o0},
+			Attr_FramedCompression:      {o0n, o0},
+			Attr_LoginIPHost:            {o0n, o0},
+			Attr_LoginService:           {o01, o0},
+			Attr_LoginTCPPort:           {o01, o0},
+			Attr_ReplyMessage:           {o0, o0},
+			Attr_CallbackNumber:         {o01, o0},
+			Attr_CallbackId:             {o01, o0},
+			Attr_FramedRoute:            {o0n, o0},
+			Attr_FramedIPXNetwork:       {o01, o0},
+			Attr_State:                  {o0, o0},
+			Attr_Class:                  {o0n, o0},
+			Attr_VendorSpecific:         {o0n, o0n},
+			Attr_SessionTimeout:         {o01, o0},
+			Attr_IdleTimeout:            {o01, o0},
+			Attr_TerminationAction:      {o01, o0},
+			Attr_CalledStationId:        {o01, o0},
+			Attr_CallingStationId:       {o01, o0},
+			Attr_NASIdentifier:          {o01, o0},
+			Attr_ProxyState:             {o0n, o0n},
+			Attr_LoginLATService:        {o01, o0},
+			Attr_LoginLATNode:           {o01, o0},
+			Attr_LoginLATGroup:          {o01, o0},
+			Attr_FramedAppleTalkLink:    {o01, o0},
+			Attr_FramedAppleTalkNetwork: {o01, o0},
+			Attr_FramedAppleTalkZone:    {o01, o0},
+			Attr_AcctStatusType:         {o1, o0},
+			Attr_AcctDelayTime:          {o01, o0},
+			Attr_AcctInputOctets:        {o01, o0},
+			Attr_AcctOutputOctets:       {o01, o0},
+			Attr_AcctSessionId:          {o1, o0},
+			Attr_AcctAuthentic:          {o01, o0},
+			Attr_AcctSessionTime:        {o01, o0},
+			Attr_AcctInputPackets:       {o01, o0},
+			Attr_AcctOutputPackets:      {o01, o0},
+			Attr_AcctTerminateCause:     {o01, o0},
+			Attr_AcctMultiSessionId:     {o01, o0},
+			Attr_AcctLinkCount:          {o01, o0},
+			Attr_CHAPChallenge:          {o0, o0},
+			Attr_NASPortType:            {o01, o0},
+			Attr_PortLimit:              {o01, o0},
+			Attr_LoginLATPort:           {o01, o0},
+		})
+}
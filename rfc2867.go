@@ -1,6 +0,0 @@
-package radius
-
-const (
-	Attr_AcctTunnelConnection  AttributeType = 68
-	Attr_AcctTunnelPacketsLost AttributeType = 86
-)
@@ -1,13 +1,20 @@
 package radius
 
-const (
-	Attr_TunnelType           AttributeType = 64
-	Attr_TunnelMediumType     AttributeType = 65
-	Attr_TunnelClientEndpoint AttributeType = 66
-	Attr_TunnelServerEndpoint AttributeType = 67
-
-	Attr_TunnelPassword       AttributeType = 69
-	Attr_TunnelPrivateGroupID AttributeType = 81
-	Attr_TunnelAssignmentID   AttributeType = 82
-	Attr_TunnelPreference     AttributeType = 83
-)
+// rfc 2868 3.6 table of tunnel attributes with accounting columns of rfc 2867 4.1
+func init() {
+	registerOccurs(
+		[]PacketType{Code_AccessRequest, Code_AccessAccept, Code_AccessReject, Code_AccessChallenge,
+			Code_AccountingRequest, Code_AccountingResponse},
+		map[AttributeType][]Occurs{
+			Attr_TunnelType:            {o0n, o0n, o0, o0, o01, o0},
+			Attr_TunnelMediumType:      {o0n, o0n, o0, o0, o01, o0},
+			Attr_TunnelClientEndpoint:  {o0n, o0n, o0, o0, o01, o0},
+			Attr_TunnelServerEndpoint:  {o0n, o0n, o0, o0, o01, o0},
+			Attr_TunnelPassword:        {o0, o0n, o0, o0, o0, o0},
+			Attr_TunnelPrivateGroupID:  {o0n, o0n, o0, o0, o01, o0},
+			Attr_TunnelAssignmentID:    {o0n, o0n, o0, o0, o01, o0},
+			Attr_TunnelPreference:      {o0n, o0n, o0, o0, o0, o0},
+			Attr_AcctTunnelConnection:  {o0, o0, o0, o0, o01, o0},
+			Attr_AcctTunnelPacketsLost: {o0, o0, o0, o0, o01, o0},
+		})
+}
@@ -1,9 +1,86 @@
 package radius
 
-const (
-	Attr_AcctInputGigawords  AttributeType = 52
-	Attr_AcctOutputGigawords AttributeType = 53
-	Attr_EventTimestamp      AttributeType = 55
-	Attr_ConnectInfo         AttributeType = 77
-	Attr_NASPortId           AttributeType = 87
-)
+import "fmt"
+
+// rfc 2869 5.19 table of attributes
+func init() {
+	registerOccurs(
+		[]PacketType{Code_AccessRequest, Code_AccessAccept, Code_AccessReject, Code_AccessChallenge,
+			Code_AccountingRequest, Code_AccountingResponse},
+		map[AttributeType][]Occurs{
+			Attr_AcctInputGigawords:    {o0, o0, o0, o0, o01, o0},
+			Attr_AcctOutputGigawords:   {o0, o0, o0, o0, o01, o0},
+			Attr_EventTimestamp:        {o0, o0, o0, o0, o01, o0},
+			Attr_ARAPPassword:          {o01, o0, o0, o0, o0, o0},
+			Attr_ARAPFeatures:          {o0, o01, o0, o01, o0, o0},
+			Attr_ARAPZoneAccess:        {o0, o01, o0, o0, o0, o0},
+			Attr_ARAPSecurity:          {o01, o0, o0, o01, o0, o0},
+			Attr_ARAPSecurityData:      {o0n, o0, o0, o0n, o0, o0},
+			Attr_PasswordRetry:         {o0, o0, o01, o0, o0, o0},
+			Attr_Prompt:                {o0, o0, o0, o01, o0, o0},
+			Attr_ConnectInfo:           {o01, o0, o0, o0, o01, o0},
+			Attr_ConfigurationToken:    {o0, o0n, o0, o0, o0, o0},
+			Attr_EAPMessage:            {o0n, o0n, o0n, o0n, o0, o0},
+			Attr_MessageAuthenticator:  {o01, o01, o01, o01, o01, o01},
+			Attr_ARAPChallengeResponse: {o0, o01, o0, o01, o0, o0},
+			Attr_AcctInterimInterval:   {o0, o01, o0, o0, o0, o0},
+			Attr_NASPortId:             {o01, o0, o0, o0, o01, o0},
+			Attr_FramedPool:            {o0, o01, o0, o0, o0, o0},
+		})
+}
+
+/*
+	Octet counters of accounting are 32 bit, Acct-Input-Gigawords and Acct-Output-Gigawords
+	count how many times they have wrapped around 2^32
+*/
+
+// InputOctets returns Acct-Input-Octets combined with Acct-Input-Gigawords,
+// ok is false if packet has no valid Acct-Input-Octets
+func (p *Packet) InputOctets() (n uint64, ok bool) {
+	return p.octets(Attr_AcctInputOctets, Attr_AcctInputGigawords)
+}
+
+// OutputOctets returns Acct-Output-Octets combined with Acct-Output-Gigawords,
+// ok is false if packet has no valid Acct-Output-Octets
+func (p *Packet) OutputOctets() (n uint64, ok bool) {
+	return p.octets(Attr_AcctOutputOctets, Attr_AcctOutputGigawords)
+}
+
+// SetInputOctets replaces Acct-Input-Octets and Acct-Input-Gigawords with the split counter
+func (p *Packet) SetInputOctets(n uint64) error {
+	return p.setOctets(Attr_AcctInputOctets, Attr_AcctInputGigawords, n)
+}
+
+// SetOutputOctets replaces Acct-Output-Octets and Acct-Output-Gigawords with the split counter
+func (p *Packet) SetOutputOctets(n uint64) error {
+	return p.setOctets(Attr_AcctOutputOctets, Attr_AcctOutputGigawords, n)
+}
+
+func (p *Packet) octets(octetsType, gigawordsType AttributeType) (n uint64, ok bool) {
+	octets := p.Attr(octetsType)
+	if octets == nil {
+		return 0, false
+	}
+	var low, high uint32
+	if err := octets.ValueUint32(&low); err != nil {
+		return 0, false
+	}
+	if gigawords := p.Attr(gigawordsType); gigawords != nil {
+		if err := gigawords.ValueUint32(&high); err != nil {
+			return 0, false
+		}
+	}
+	return uint64(high)<<32 | uint64(low), true
+}
+
+func (p *Packet) setOctets(octetsType, gigawordsType AttributeType, n uint64) error {
+	p.DelAttrs(octetsType)
+	p.DelAttrs(gigawordsType)
+	if err := p.AddAttribute(octetsType, uint32(n)); err != nil {
+		return fmt.Errorf("set %s: %v", octetsType, err)
+	}
+	if err := p.AddAttribute(gigawordsType, uint32(n>>32)); err != nil {
+		return fmt.Errorf("set %s: %v", gigawordsType, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package radius
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPacket_SetInputOctets(t *testing.T) {
+	p := NewPacket(Code_AccountingRequest, []byte("ctrhtn"))
+	p.AddAttribute(Attr_AcctInputOctets, uint32(1))
+	if err := p.SetInputOctets(5<<32 + 17); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetOutputOctets(42); err != nil {
+		t.Fatal(err)
+	}
+	p.AddAttribute(Attr_EventTimestamp, time.Unix(1542844800, 0))
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &Packet{Wire: p.Wire}
+	if err := req.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Attrs(Attr_AcctInputOctets)) != 1 {
+		t.Errorf("Expected single Acct-Input-Octets got %d", len(req.Attrs(Attr_AcctInputOctets)))
+	}
+	if n, ok := req.InputOctets(); !ok || n != 5<<32+17 {
+		t.Errorf("Expected %d got %d", uint64(5<<32+17), n)
+	}
+	if n, ok := req.OutputOctets(); !ok || n != 42 {
+		t.Errorf("Expected 42 got %d", n)
+	}
+	if ts, ok := req.Attr(Attr_EventTimestamp).Value.(time.Time); !ok || ts.Unix() != 1542844800 {
+		t.Errorf("Expected 1542844800 got %v", req.Attr(Attr_EventTimestamp).Value)
+	}
+
+	if _, ok := NewPacket(Code_AccountingRequest, nil).InputOctets(); ok {
+		t.Error("Expected no input octets")
+	}
+}
+
+func TestEncoderDate_Uint32(t *testing.T) {
+	p := NewPacket(Code_AccountingRequest, []byte("ctrhtn"))
+	if err := p.AddAttribute(Attr_EventTimestamp, uint32(1542844800)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	req := &Packet{Wire: p.Wire}
+	if err := req.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if ts, ok := req.Attr(Attr_EventTimestamp).Value.(time.Time); !ok || ts.Unix() != 1542844800 {
+		t.Errorf("Expected 1542844800 got %v", req.Attr(Attr_EventTimestamp).Value)
+	}
+}
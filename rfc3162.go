@@ -1,11 +1,16 @@
 package radius
 
-const (
-	Attr_NASIPv6Address    AttributeType = 95  //NAS-IPv6-Address
-	Attr_FramedInterfaceId AttributeType = 96  //Framed-Interface-Id
-	Attr_FramedIPv6Prefix  AttributeType = 97  //Framed-IPv6-Prefix
-	Attr_LoginIPv6Host     AttributeType = 98  //Login-IPv6-Host
-	Attr_FramedIPv6Route   AttributeType = 99  //Framed-IPv6-Route
-	Attr_FramedIPv6Pool    AttributeType = 100 //Framed-IPv6-Pool
-
-)
+// rfc 3162 3 table of IPv6 attributes
+func init() {
+	registerOccurs(
+		[]PacketType{Code_AccessRequest, Code_AccessAccept, Code_AccessReject, Code_AccessChallenge,
+			Code_AccountingRequest, Code_AccountingResponse},
+		map[AttributeType][]Occurs{
+			Attr_NASIPv6Address:    {o01, o0, o0, o0, o01, o0},
+			Attr_FramedInterfaceId: {o01, o01, o0, o0, o01, o0},
+			Attr_FramedIPv6Prefix:  {o0n, o0n, o0, o0, o0n, o0},
+			Attr_LoginIPv6Host:     {o0n, o0n, o0, o0, o0n, o0},
+			Attr_FramedIPv6Route:   {o0, o0n, o0, o0, o0n, o0},
+			Attr_FramedIPv6Pool:    {o0, o01, o0, o0, o01, o0},
+		})
+}
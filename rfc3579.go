@@ -1,6 +1,104 @@
 package radius
 
-const (
-	Attr_EAPMessage           AttributeType = 79
-	Attr_MessageAuthenticator AttributeType = 80
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"errors"
 )
+
+/*
+	rfc 3579 3.2 Message-Authenticator is HMAC-MD5 with the secret over packet with
+	Message-Authenticator zeroed, responses are hashed with Request Authenticator
+	in place of their own, accounting and dynamic authorization requests with zero one.
+	Encode signs Message-Authenticator of packet with SignMessageAuthenticator.
+*/
+
+// CheckMessageAuthenticator checks Message-Authenticator of decoded packet,
+// RequestAuthenticator must be set for responses
+func (p *Packet) CheckMessageAuthenticator(secret []byte) (res bool, err error) {
+	if len(p.Wire) < int(p.length) || p.length < MinPacketLength {
+		return false, errors.New("packet is not decoded")
+	}
+	wire := append([]byte(nil), p.Wire[:p.length]...)
+	var sum []byte
+	for offset := MinPacketLength; offset+2 <= len(wire); offset += int(wire[offset+1]) {
+		length := int(wire[offset+1])
+		if length < 2 || offset+length > len(wire) {
+			break
+		}
+		if AttributeType(wire[offset]) == Attr_MessageAuthenticator {
+			if length != 18 || sum != nil {
+				return false, errors.New("invalid Message-Authenticator")
+			}
+			sum = append(sum, wire[offset+2:offset+18]...)
+			copy(wire[offset+2:offset+18], make([]byte, 16))
+		}
+	}
+	if sum == nil {
+		return false, errors.New("packet has no Message-Authenticator")
+	}
+	switch {
+	case p.Type == Code_AccessRequest || p.Type == Code_StatusServer:
+	case isRequestCode(p.Type):
+		// rfc 5176 3.5, Request Authenticator of these requests is hash itself
+		copy(wire[4:20], make([]byte, 16))
+	default:
+		copy(wire[4:20], p.RequestAuthenticator[:])
+	}
+	mac := hmac.New(md5.New, secret)
+	mac.Write(wire)
+	return bytes.Equal(mac.Sum(nil), sum), nil
+}
+
+// signMessageAuthenticator signs Message-Authenticator of encoded attributes before the packet
+// authenticator is made, Authenticator of Access-Request and Status-Server is made first as it's hashed
+func (p *Packet) signMessageAuthenticator(length int) error {
+	if !p.SignMessageAuthenticator {
+		return nil
+	}
+	attrs := p.attrsBuff.Bytes()
+	offset := -1
+	for i := 0; i+2 <= len(attrs) && attrs[i+1] >= 2; i += int(attrs[i+1]) {
+		if AttributeType(attrs[i]) == Attr_MessageAuthenticator && attrs[i+1] == 18 {
+			offset = i + 2
+		}
+	}
+	if offset < 0 {
+		return nil
+	}
+	var authenticator [16]byte
+	switch {
+	case p.Type == Code_AccessRequest || p.Type == Code_StatusServer:
+		authenticator = p.Authenticator
+	case isRequestCode(p.Type):
+	default:
+		authenticator = p.RequestAuthenticator
+	}
+	copy(attrs[offset:offset+16], make([]byte, 16))
+	mac := hmac.New(md5.New, p.Secret)
+	mac.Write([]byte{byte(p.Type), p.Identifier, byte(length >> 8), byte(length)})
+	mac.Write(authenticator[:])
+	mac.Write(attrs)
+	sum := mac.Sum(nil)
+	copy(attrs[offset:offset+16], sum)
+	// attribute shows the signed value as decoded packet does
+	for i := len(p.Attributes) - 1; i >= 0; i-- {
+		if a := p.Attributes[i]; a.Type == Attr_MessageAuthenticator {
+			a.Value = sum
+			a.Wire = append([]byte{byte(Attr_MessageAuthenticator), 18}, sum...)
+			break
+		}
+	}
+	return nil
+}
+
+// isRequestCode reports whether packet of the code is sent by client
+func isRequestCode(code PacketType) bool {
+	switch code {
+	case Code_AccessRequest, Code_AccountingRequest, Code_StatusServer,
+		Code_DisconnectRequest, Code_CoARequest:
+		return true
+	}
+	return false
+}
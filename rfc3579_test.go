@@ -0,0 +1,67 @@
+package radius
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"testing"
+)
+
+func TestPacket_CheckMessageAuthenticator(t *testing.T) {
+	secret := []byte("ctrhtn")
+	req := NewPacket(Code_StatusServer, secret)
+	req.AddAttribute(Attr_MessageAuthenticator, make([]byte, 16))
+	if err := req.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(md5.New, secret)
+	mac.Write(req.Wire)
+	copy(req.Wire[len(req.Wire)-16:], mac.Sum(nil))
+
+	p := &Packet{Wire: req.Wire}
+	if err := p.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := p.CheckMessageAuthenticator(secret); err != nil || !ok {
+		t.Errorf("Expected valid Message-Authenticator got %v (%v)", ok, err)
+	}
+	if ok, _ := p.CheckMessageAuthenticator([]byte("wrong")); ok {
+		t.Error("Expected invalid Message-Authenticator with wrong secret")
+	}
+
+	for _, code := range []PacketType{Code_AccessAccept, Code_AccountingRequest} {
+		out := NewPacket(code, secret)
+		out.RequestAuthenticator = req.Authenticator
+		out.SignMessageAuthenticator = true
+		out.AddAttribute(Attr_ReplyMessage, "hi")
+		if err := out.Encode(); err != nil {
+			t.Fatal(err)
+		}
+		in := &Packet{Wire: out.Wire, RequestAuthenticator: req.Authenticator}
+		if err := in.Decode(); err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := in.CheckMessageAuthenticator(secret); err != nil || !ok {
+			t.Errorf("%s: Expected valid Message-Authenticator got %v (%v)", code, ok, err)
+		}
+		if sent, got := out.Attr(Attr_MessageAuthenticator).Value.([]byte), in.Attr(Attr_MessageAuthenticator).Value.([]byte); !bytes.Equal(sent, got) {
+			t.Errorf("%s: Expected signed Message-Authenticator %x in attributes got %x", code, got, sent)
+		}
+		check := in.CheckResponseAuthenticator
+		if code == Code_AccountingRequest {
+			check = in.CheckAccountingRequestAuthenticator
+		}
+		if ok, err := check(secret); err != nil || !ok {
+			t.Errorf("%s: Expected valid authenticator over Message-Authenticator got %v (%v)", code, ok, err)
+		}
+	}
+
+	p = &Packet{Wire: append(req.Wire[:20:20], byte(Attr_UserName), 3, 'x')}
+	p.Wire[3] = byte(len(p.Wire))
+	if err := p.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.CheckMessageAuthenticator(secret); err == nil {
+		t.Error("Expected error of packet without Message-Authenticator")
+	}
+}
@@ -1,8 +1,130 @@
 package radius
 
-/*
-"Unsupported-Service (405)"
-"Session-Context-Not-Found (503)"
-"Unsupported-Extension (406)"
-*/
-const Attr_ErrorCause AttributeType = 101
+import (
+	"errors"
+	"fmt"
+)
+
+// rfc 5176 3.6 table of attributes, CoA-Request may carry authorization attributes of Access-Accept
+func init() {
+	registerOccurs(
+		[]PacketType{Code_DisconnectRequest, Code_DisconnectACK, Code_DisconnectNAK,
+			Code_CoARequest, Code_CoAACK, Code_CoANAK},
+		map[AttributeType][]Occurs{
+			Attr_UserName:             {o01, o0, o0, o01, o0, o0},
+			Attr_NASIPAddress:         {o01, o0, o0, o01, o0, o0},
+			Attr_NASPort:              {o01, o0, o0, o01, o0, o0},
+			Attr_ServiceType:          {o0, o0, o0, o01, o0, o01},
+			Attr_FramedIPAddress:      {o01, o0, o0, o01, o0, o0},
+			Attr_ReplyMessage:         {o0n, o0, o0, o0n, o0, o0},
+			Attr_State:                {o01, o0, o01, o01, o0, o01},
+			Attr_VendorSpecific:       {o0n, o0n, o0n, o0n, o0n, o0n},
+			Attr_CalledStationId:      {o01, o0, o0, o01, o0, o0},
+			Attr_CallingStationId:     {o01, o0, o0, o01, o0, o0},
+			Attr_NASIdentifier:        {o01, o0, o0, o01, o0, o0},
+			Attr_ProxyState:           {o0n, o0n, o0n, o0n, o0n, o0n},
+			Attr_AcctSessionId:        {o01, o0, o0, o01, o0, o0},
+			Attr_AcctMultiSessionId:   {o01, o0, o0, o01, o0, o0},
+			Attr_EventTimestamp:       {o01, o01, o01, o01, o01, o01},
+			Attr_MessageAuthenticator: {o01, o01, o01, o01, o01, o01},
+			Attr_NASPortId:            {o01, o0, o0, o01, o0, o0},
+			Attr_NASIPv6Address:       {o01, o0, o0, o01, o0, o0},
+			Attr_FramedInterfaceId:    {o01, o0, o0, o01, o0, o0},
+			Attr_FramedIPv6Prefix:     {o0n, o0, o0, o0n, o0, o0},
+			Attr_ErrorCause:           {o0, o0n, o0n, o0, o0n, o0n},
+		})
+	codeOccursFallback[Code_CoARequest] = Code_AccessAccept
+}
+
+// Error makes Error-Cause usable as Go error, so handlers may return it and
+// errors of NAK replies can be checked with errors.Is
+func (v ErrorCause) Error() string {
+	return v.String()
+}
+
+// IsSuccess reports 2xx causes which are sent in ACK
+func (v ErrorCause) IsSuccess() bool {
+	return v >= 200 && v < 300
+}
+
+// IsClientError reports 4xx causes: the request is wrong
+func (v ErrorCause) IsClientError() bool {
+	return v >= 400 && v < 500
+}
+
+// IsNASError reports 5xx causes: the NAS can't fulfill valid request
+func (v ErrorCause) IsNASError() bool {
+	return v >= 500 && v < 600
+}
+
+// NAKError is the error of CoA-NAK and Disconnect-NAK replies
+type NAKError struct {
+	Code PacketType
+	// Cause is zero if the reply has no Error-Cause
+	Cause ErrorCause
+}
+
+func (e *NAKError) Error() string {
+	if e.Cause == 0 {
+		return fmt.Sprintf("radius: %s without Error-Cause", e.Code)
+	}
+	return fmt.Sprintf("radius: %s: %s", e.Code, e.Cause)
+}
+
+func (e *NAKError) Unwrap() error {
+	if e.Cause == 0 {
+		return nil
+	}
+	return e.Cause
+}
+
+// ErrorCauseOf returns Error-Cause of the err chain, other errors are Resources-Unavailable
+func ErrorCauseOf(err error) ErrorCause {
+	var cause ErrorCause
+	if errors.As(err, &cause) {
+		return cause
+	}
+	return ErrorCause_ResourcesUnavailable
+}
+
+// NewNAK makes CoA-NAK or Disconnect-NAK reply to the request with Error-Cause of err, causes
+// which aren't errors (not 4xx or 5xx) are sent as Resources-Unavailable, rfc 5176 3.5
+func NewNAK(request *Packet, err error) (*Packet, error) {
+	var code PacketType
+	switch request.Type {
+	case Code_CoARequest:
+		code = Code_CoANAK
+	case Code_DisconnectRequest:
+		code = Code_DisconnectNAK
+	default:
+		return nil, fmt.Errorf("can't make NAK to %s", request.Type)
+	}
+	nak := &Packet{
+		Type:                 code,
+		Identifier:           request.Identifier,
+		RequestAuthenticator: request.Authenticator,
+		Secret:               request.Secret,
+	}
+	cause := ErrorCauseOf(err)
+	if !cause.IsClientError() && !cause.IsNASError() {
+		cause = ErrorCause_ResourcesUnavailable
+	}
+	if err := nak.AddAttribute(Attr_ErrorCause, cause); err != nil {
+		return nil, err
+	}
+	return nak, nil
+}
+
+// ReplyError returns *NAKError of CoA-NAK and Disconnect-NAK and nil for other packets
+func (p *Packet) ReplyError() error {
+	if p.Type != Code_CoANAK && p.Type != Code_DisconnectNAK {
+		return nil
+	}
+	nakErr := &NAKError{Code: p.Type}
+	if a := p.Attr(Attr_ErrorCause); a != nil {
+		if cause, ok := uint32Value(a.Value); ok {
+			nakErr.Cause = ErrorCause(cause)
+		}
+	}
+	return nakErr
+}
@@ -0,0 +1,87 @@
+package radius
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestErrorCauseOf(t *testing.T) {
+	err := fmt.Errorf("session %s: %w", "abc", ErrorCause_SessionContextNotFound)
+	if cause := ErrorCauseOf(err); cause != ErrorCause_SessionContextNotFound {
+		t.Errorf("Expected %s got %s", ErrorCause_SessionContextNotFound, cause)
+	}
+	if cause := ErrorCauseOf(errors.New("db is down")); cause != ErrorCause_ResourcesUnavailable {
+		t.Errorf("Expected %s got %s", ErrorCause_ResourcesUnavailable, cause)
+	}
+	if !ErrorCause_UnsupportedAttribute.IsClientError() || ErrorCause_UnsupportedAttribute.IsNASError() {
+		t.Errorf("Expected %s to be client error", ErrorCause_UnsupportedAttribute)
+	}
+}
+
+func TestNewNAK(t *testing.T) {
+	request := NewPacket(Code_CoARequest, []byte("ctrhtn"))
+	for _, c := range []struct {
+		err   error
+		cause ErrorCause
+	}{
+		{ErrorCause_UnsupportedAttribute, ErrorCause_UnsupportedAttribute},
+		{ErrorCause_SessionContextNotFound, ErrorCause_SessionContextNotFound},
+		{ErrorCause_ResidualSessionContextRemoved, ErrorCause_ResourcesUnavailable},
+		{ErrorCause(100), ErrorCause_ResourcesUnavailable},
+	} {
+		nak, err := NewNAK(request, c.err)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cause := nak.Attr(Attr_ErrorCause).Value; cause != c.cause {
+			t.Errorf("%v: Expected %s got %v", c.err, c.cause, cause)
+		}
+	}
+}
+
+func TestExchangeCoA_NAK(t *testing.T) {
+	secret := []byte("ctrhtn")
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	go func() {
+		buff := make([]byte, MaxPacketLength)
+		n, addr, err := conn.ReadFromUDP(buff)
+		if err != nil {
+			return
+		}
+		request := &Packet{Wire: buff[:n]}
+		if err := request.Decode(); err != nil {
+			return
+		}
+		request.Secret = secret
+		nak, err := NewNAK(request, fmt.Errorf("no session: %w", ErrorCause_SessionContextNotFound))
+		if err != nil {
+			return
+		}
+		if err := nak.Encode(); err != nil {
+			return
+		}
+		conn.WriteToUDP(nak.Wire, addr)
+	}()
+
+	request := NewPacket(Code_DisconnectRequest, secret)
+	request.AddAttribute(Attr_AcctSessionId, "abc")
+	reply, err := ExchangeCoA(request, conn.LocalAddr().(*net.UDPAddr), 1, time.Second)
+	if reply == nil || reply.Type != Code_DisconnectNAK {
+		t.Fatalf("Expected %s got %v (%v)", Code_DisconnectNAK, reply, err)
+	}
+	if !errors.Is(err, ErrorCause_SessionContextNotFound) {
+		t.Errorf("Expected %s got %v", ErrorCause_SessionContextNotFound, err)
+	}
+	var nakErr *NAKError
+	if !errors.As(err, &nakErr) || nakErr.Code != Code_DisconnectNAK {
+		t.Errorf("Expected *NAKError got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package radius
+
+import (
+	l "github.com/sirupsen/logrus"
+)
+
+// StatusServerPolicy chooses how Server answers Status-Server, rfc 5997
+type StatusServerPolicy int
+
+const (
+	// StatusServerPolicy_Reply answers Status-Server before Handler with Access-Accept on
+	// authentication listeners and Accounting-Response on accounting listeners, listeners of any
+	// code answer Accounting-Response on ports 1813 and 1646
+	StatusServerPolicy_Reply StatusServerPolicy = iota
+	// StatusServerPolicy_Statistics replies like StatusServerPolicy_Reply with global counters of
+	// Server.Stats which are requested by FreeRADIUS-Statistics-Type
+	StatusServerPolicy_Statistics
+	// StatusServerPolicy_Handler passes Status-Server to Handler
+	StatusServerPolicy_Handler
+)
+
+/*
+serveStatus answers Status-Server, which Message-Authenticator is verified by authenticate.
+Status-Server without Message-Authenticator is discarded, rfc 5997 3.
+*/
+func (s *Server) serveStatus(w *udpResponseWriter) {
+	r := w.request
+	if r.Packet.Attr(Attr_MessageAuthenticator) == nil {
+		l.Warnf(" packet Status-Server without Message-Authenticator from %s", r.RemoteAddr)
+		s.count(r, statsMalformed)
+		return
+	}
+	acct := acctServer(r)
+	reply := &Packet{Type: Code_AccessAccept}
+	if acct {
+		reply.Type = Code_AccountingResponse
+	}
+	if s.StatusServer == StatusServerPolicy_Statistics && s.Stats != nil {
+		if err := s.addStatistics(reply, r.Packet); err != nil {
+			l.Errorf(" packet Status-Server statistics: %v", err)
+			return
+		}
+	}
+	// Message-Authenticator of reply is added as the request has it
+	if err := prepareReply(reply, r); err != nil {
+		l.Errorf(" packet Status-Server reply: %v", err)
+		return
+	}
+	w.writeWire(reply.Wire)
+}
+
+// addStatistics adds counters of FreeRADIUS-Statistics-Type of request to reply
+func (s *Server) addStatistics(reply, request *Packet) error {
+	vsa := request.VSA(Vendor_FreeRADIUS, VSA_FreeRADIUSStatisticsType)
+	if vsa == nil {
+		return nil
+	}
+	requested, _ := uint32Value(vsa.Data)
+	a, err := NewVendorAttribute(Vendor_FreeRADIUS)
+	if err != nil {
+		return err
+	}
+	add := func(vendorType uint8, value uint64) {
+		// counters of rfc 4669 are 32 bit and wrap
+		if err == nil {
+			err = a.AddVSA(vendorType, uint32(value))
+		}
+	}
+	c := s.Stats.Global()
+	if requested&uint32(FreeRADIUSStatisticsType_Authentication) != 0 {
+		add(VSA_FreeRADIUSTotalAccessRequests, c.AccessRequests)
+		add(VSA_FreeRADIUSTotalAccessAccepts, c.AccessAccepts)
+		add(VSA_FreeRADIUSTotalAccessRejects, c.AccessRejects)
+		add(VSA_FreeRADIUSTotalAccessChallenges, c.AccessChallenges)
+		add(VSA_FreeRADIUSTotalAuthResponses, c.AccessAccepts+c.AccessRejects+c.AccessChallenges)
+		add(VSA_FreeRADIUSTotalAuthDuplicateRequests, c.DupAccessRequests)
+		add(VSA_FreeRADIUSTotalAuthMalformedRequests, c.MalformedAccessRequests)
+		add(VSA_FreeRADIUSTotalAuthInvalidRequests, c.AuthInvalidRequests)
+		add(VSA_FreeRADIUSTotalAuthDroppedRequests, c.AuthPacketsDropped)
+		add(VSA_FreeRADIUSTotalAuthUnknownTypes, c.AuthUnknownTypes)
+	}
+	if requested&uint32(FreeRADIUSStatisticsType_Accounting) != 0 {
+		add(VSA_FreeRADIUSTotalAccountingRequests, c.AccountingRequests)
+		add(VSA_FreeRADIUSTotalAccountingResponses, c.AccountingResponses)
+		add(VSA_FreeRADIUSTotalAcctDuplicateRequests, c.DupAccountingRequests)
+		add(VSA_FreeRADIUSTotalAcctMalformedRequests, c.MalformedAccountingRequests)
+		add(VSA_FreeRADIUSTotalAcctInvalidRequests, c.AcctInvalidRequests)
+		add(VSA_FreeRADIUSTotalAcctDroppedRequests, c.AcctPacketsDropped)
+		add(VSA_FreeRADIUSTotalAcctUnknownTypes, c.AcctUnknownTypes)
+	}
+	if err != nil {
+		return err
+	}
+	if len(a.Pairs) == 0 {
+		return nil
+	}
+	if err := a.AddVSA(VSA_FreeRADIUSStatsStartTime, s.Start); err != nil {
+		return err
+	}
+	reply.AddAttr(a)
+	return nil
+}
@@ -0,0 +1,133 @@
+package radius
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServer_StatusServer(t *testing.T) {
+	secret := []byte("ctrhtn")
+	clients := &ClientTable{}
+	clients.Add("127.0.0.1", &Client{Name: "local", Secret: secret})
+	loopback := net.IPv4(127, 0, 0, 1)
+	auth := &net.UDPAddr{IP: loopback, Port: freeUDPPort(t, "udp4", loopback)}
+	acct := &net.UDPAddr{IP: loopback, Port: freeUDPPort(t, "udp4", loopback)}
+	s := &Server{
+		Listeners:    []*Listener{AuthListener(auth.String()), AcctListener(acct.String())},
+		Secrets:      clients,
+		Stats:        &Stats{},
+		StatusServer: StatusServerPolicy_Statistics,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			if r.Packet.Type == Code_StatusServer {
+				t.Error("Expected Status-Server to be answered by Server")
+			}
+			w.Write(&Packet{Type: Code_AccessAccept})
+		}),
+	}
+	started := time.Now().Truncate(time.Second)
+	errs := make(chan error, 1)
+	go func() { errs <- s.ListenAndServe() }()
+	t.Cleanup(func() { s.Close() })
+	waitBound(t, "udp4", acct)
+
+	if _, err := ExchangePacket(NewPacket(Code_AccessRequest, secret), auth, 1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	statusServer := func(statistics FreeRADIUSStatisticsType) *Packet {
+		p := NewPacket(Code_StatusServer, secret)
+		p.SignMessageAuthenticator = true
+		if statistics != FreeRADIUSStatisticsType_None {
+			a, _ := NewVendorAttribute(Vendor_FreeRADIUS)
+			a.AddVSA(VSA_FreeRADIUSStatisticsType, statistics)
+			p.AddAttr(a)
+		}
+		return p
+	}
+
+	reply, err := ExchangePacket(statusServer(FreeRADIUSStatisticsType_None), auth, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != Code_AccessAccept || reply.Attr(Attr_MessageAuthenticator) == nil {
+		t.Errorf("Expected Access-Accept with Message-Authenticator got %s", reply)
+	}
+	if reply.VSA(Vendor_FreeRADIUS, VSA_FreeRADIUSTotalAccessRequests) != nil {
+		t.Error("Expected no statistics without FreeRADIUS-Statistics-Type")
+	}
+
+	reply, err = ExchangePacket(statusServer(FreeRADIUSStatisticsType_Authentication), acct, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != Code_AccountingResponse {
+		t.Errorf("Expected Accounting-Response got %s", reply.Type)
+	}
+	if vsa := reply.VSA(Vendor_FreeRADIUS, VSA_FreeRADIUSTotalAccessRequests); vsa == nil || vsa.Data != uint32(1) {
+		t.Errorf("Expected 1 Access-Request in statistics got %+v", vsa)
+	}
+	if vsa := reply.VSA(Vendor_FreeRADIUS, VSA_FreeRADIUSTotalAccountingRequests); vsa != nil {
+		t.Errorf("Expected no accounting statistics got %+v", vsa)
+	}
+	if vsa := reply.VSA(Vendor_FreeRADIUS, VSA_FreeRADIUSStatsStartTime); vsa == nil || vsa.Data.(time.Time).Before(started) {
+		t.Errorf("Expected start time after %s got %+v", started, vsa)
+	}
+
+	// Message-Authenticator is required
+	p := NewPacket(Code_StatusServer, secret)
+	if _, err := ExchangePacket(p, auth, 1, 200*time.Millisecond); err == nil {
+		t.Error("Expected Status-Server without Message-Authenticator to be dropped")
+	}
+	p = statusServer(FreeRADIUSStatisticsType_None)
+	p.Secret = []byte("wrong")
+	if _, err := ExchangePacket(p, auth, 1, 200*time.Millisecond); err == nil {
+		t.Error("Expected Status-Server with invalid Message-Authenticator to be dropped")
+	}
+}
+
+func TestAcctServer(t *testing.T) {
+	for _, c := range []struct {
+		listener *Listener
+		port     int
+		acct     bool
+	}{
+		{AuthListener(), 1813, false},
+		{AcctListener(), 1812, true},
+		{&Listener{}, 1812, false},
+		{&Listener{}, 1813, true},
+		{&Listener{}, 1646, true},
+		{nil, 1813, true},
+		{nil, 1645, false},
+	} {
+		r := testRequest(Code_StatusServer)
+		r.Listener = c.listener
+		r.LocalAddr = &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: c.port}
+		if acct := acctServer(r); acct != c.acct {
+			t.Errorf("%+v on %d: Expected %v got %v", c.listener, c.port, c.acct, acct)
+		}
+	}
+}
+
+func TestServer_StatusServerHandler(t *testing.T) {
+	secret := []byte("ctrhtn")
+	clients := &ClientTable{}
+	clients.Add("127.0.0.1", &Client{Name: "local", Secret: secret})
+	s := &Server{
+		Secrets:      clients,
+		StatusServer: StatusServerPolicy_Handler,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			w.Write(&Packet{Type: Code_AccessReject})
+		}),
+	}
+	addr, _ := startServer(t, s)
+	p := NewPacket(Code_StatusServer, secret)
+	p.SignMessageAuthenticator = true
+	reply, err := ExchangePacket(p, addr, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != Code_AccessReject {
+		t.Errorf("Expected reply of Handler got %s", reply.Type)
+	}
+}
@@ -1,32 +1,107 @@
 package radius
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	l "github.com/sirupsen/logrus"
 	"net"
+	"sync"
 	"time"
 )
 
+// ErrServerClosed is returned by ListenAndServe after Shutdown or Close
+var ErrServerClosed = errors.New("radius: Server closed")
+
+// DefaultRequestTimeout is deadline of handler context if Server.RequestTimeout is 0
+const DefaultRequestTimeout = 10 * time.Second
+
 type Request struct {
 	Start time.Time
 	Secret     []byte
+	// Client is set by Server.Secrets
+	Client     *Client
+	// Listener and LocalAddr are where request is received
+	Listener   *Listener
+	LocalAddr  *net.UDPAddr
 	RemoteAddr *net.UDPAddr
 	Packet     *Packet
-	//deprecated
+	// Deprecated: use context of Handler
 	// нужен для того чтобы, по мере прохождения запроса через бизнес логику,
 	// сохранять попутнуб информация типо полей для сквозного логирования etc ...
 	Context interface{}
 }
+
+// Handler serves request, the context is done at the request deadline or when the server is closed
 type Handler interface {
+	ServeRADIUS(ctx context.Context, w ResponseWriter, r *Request)
+}
+
+type HandlerFunc func(ctx context.Context, w ResponseWriter, r *Request)
+
+func (f HandlerFunc) ServeRADIUS(ctx context.Context, w ResponseWriter, r *Request) {
+	f(ctx, w, r)
+}
+
+/*
+LegacyHandler is Handler of previous release which writes reply to the connection itself,
+Legacy adapts it to Handler:
+
+	s := &radius.Server{Handler: radius.Legacy(radius.LegacyHandlerFunc(serve))}
+
+Replies written to the connection aren't seen by Server: they aren't counted in Stats and
+kept in Duplicates, requests served by LegacyHandler aren't counted as dropped either and their
+retransmissions are served again.
+
+Deprecated: LegacyHandler is removed in the next release, use Handler and ResponseWriter
+*/
+type LegacyHandler interface {
 	ServeRequest(*net.UDPConn, *Request)
 }
 
-type HandlerFunc func(*net.UDPConn, *Request)
+// Deprecated: use HandlerFunc
+type LegacyHandlerFunc func(*net.UDPConn, *Request)
 
-func (f HandlerFunc) ServeRequest(conn *net.UDPConn, r *Request) {
+func (f LegacyHandlerFunc) ServeRequest(conn *net.UDPConn, r *Request) {
 	f(conn, r)
 }
 
+// writerContextKey is context key of udpResponseWriter of request served by Server
+type writerContextKey struct{}
+
+// Legacy adapts LegacyHandler to Handler, it's handed the connection request is received on by Server
+//
+// Deprecated: use Handler
+func Legacy(h LegacyHandler) Handler {
+	return HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+		uw, ok := ctx.Value(writerContextKey{}).(*udpResponseWriter)
+		if !ok {
+			l.Errorf(" packet %s from %s: legacy handler isn't served by Server", r.Packet.Type, r.RemoteAddr)
+			return
+		}
+		// reply is written to the connection, Server doesn't know if there is one
+		uw.legacy = true
+		h.ServeRequest(uw.conn, r)
+	})
+}
+
+// ResponseWriter sends reply of request over the transport it came from
+type ResponseWriter interface {
+	/*
+		Write fills Identifier and RequestAuthenticator of reply from the request, Secret if it's empty,
+		adds Message-Authenticator if request has it or EAP-Message, encodes and sends reply.
+		Only one reply can be written, invalid reply isn't sent with ValidationPolicy_Drop.
+	*/
+	Write(reply *Packet) error
+	LocalAddr() net.Addr
+	RemoteAddr() net.Addr
+}
+
+// Tap receives datagrams read and written by Server, pcap.Writer is one
+type Tap interface {
+	WriteDatagram(t time.Time, src, dst *net.UDPAddr, payload []byte) error
+}
+
 /**
 Example server
  */
@@ -37,55 +112,146 @@ type Server struct {
 	// Network of the server. Valid values are "udp", "udp4", "udp6". If empty,
 	// the network defaults to "udp".
 	Network string
-	// Listener
-	connection *net.UDPConn
+	// Listeners, if set, are bound instead of Addr
+	Listeners []*Listener
+	// connections of all listeners
+	connections []*net.UDPConn
 
 	Handler Handler
+
+	// Validation is what to do with requests and replies which fail Packet.Validate
+	Validation ValidationPolicy
+
+	// Tap captures received requests and replies
+	Tap Tap
+
+	// RequestTimeout is deadline of handler context, DefaultRequestTimeout if 0
+	RequestTimeout time.Duration
+
+	// Duplicates detects retransmitted requests, nil disables detection
+	Duplicates *DuplicateCache
+
+	// Secrets, if set, fills Request.Secret and Request.Client, requests of unknown clients
+	// and with invalid authenticators are dropped
+	Secrets SecretSource
+	// Stats, if set, counts requests and replies
+	Stats *Stats
+	// StatusServer chooses how Status-Server is answered. Server answers it only with Secrets
+	// as Message-Authenticator of Status-Server must be verified, without Secrets it's passed
+	// to Handler whatever the policy is
+	StatusServer StatusServerPolicy
+
+	// MaxConcurrency bounds number of running handlers, 0 runs a goroutine per request
+	MaxConcurrency int
+	// QueueSize bounds requests waiting for a handler with MaxConcurrency, DefaultQueueSize if 0
+	QueueSize int
+	// DropPolicy chooses request dropped when the queue is full
+	DropPolicy DropPolicy
+	queue      *requestQueue
+
+	mu sync.Mutex
+	// closed is set by Shutdown and Close
+	closed bool
+	// handlers counts requests in flight
+	handlers sync.WaitGroup
+	// baseCtx is parent of handler contexts, canceled when server is closed
+	baseCtx    context.Context
+	cancelBase context.CancelFunc
 }
 
-// ListenAndServe starts a RADIUS server on the address given in s.
+// ListenAndServe starts a RADIUS server on the address given in s or on its Listeners.
 func (s *Server) ListenAndServe() error {
 	s.Start = time.Now()
-	var (
-		err  error
-		addr *net.UDPAddr
-	)
-	if s.connection != nil {
-		return errors.New("radius: server already started")
-	}
-
 	if s.Handler == nil {
 		return errors.New("radius: nil Handler")
 	}
 
-	addrStr := ":1812"
-	if s.Addr != "" {
-		addrStr = s.Addr
+	listeners := s.Listeners
+	if len(listeners) == 0 {
+		addrStr := ":1812"
+		if s.Addr != "" {
+			addrStr = s.Addr
+		}
+		if s.Network == "" {
+			s.Network = "udp"
+		}
+		listeners = []*Listener{{Addrs: []string{addrStr}, Network: s.Network}}
+	}
+
+	s.mu.Lock()
+	switch {
+	case s.closed:
+		s.mu.Unlock()
+		return ErrServerClosed
+	case s.connections != nil:
+		s.mu.Unlock()
+		return errors.New("radius: server already started")
 	}
-	if s.Network == "" {
-		s.Network = "udp"
+	var bound []*Listener
+	for _, listener := range listeners {
+		conns, err := listener.listen()
+		if err != nil {
+			for _, conn := range s.connections {
+				conn.Close()
+			}
+			s.connections = nil
+			s.mu.Unlock()
+			return err
+		}
+		for _, conn := range conns {
+			s.connections = append(s.connections, conn)
+			bound = append(bound, listener)
+		}
 	}
-	if addr, err = net.ResolveUDPAddr(s.Network, addrStr); err != nil {
-		return err
+	s.baseCtx, s.cancelBase = context.WithCancel(context.Background())
+	if s.MaxConcurrency > 0 {
+		s.queue = newRequestQueue(s.QueueSize, s.DropPolicy)
+		defer s.queue.close()
+		for i := 0; i < s.MaxConcurrency; i++ {
+			go s.worker()
+		}
 	}
-	if s.connection, err = net.ListenUDP(s.Network, addr); err != nil {
-		return err
+	conns := s.connections
+	s.mu.Unlock()
+
+	errs := make(chan error, len(conns))
+	for i, conn := range conns {
+		go func(conn *net.UDPConn, listener *Listener) {
+			errs <- s.serveConn(conn, listener)
+		}(conn, bound[i])
+	}
+	// failure of one connection closes the others
+	err := ErrServerClosed
+	for range conns {
+		if connErr := <-errs; connErr != ErrServerClosed && err == ErrServerClosed {
+			err = connErr
+		}
 	}
-	defer s.connection.Close()
+	return err
+}
 
-	s.connection.SetReadBuffer(4194304)
-	s.connection.SetWriteBuffer(4194304)
+// serveConn reads requests of the connection until the server is closed
+func (s *Server) serveConn(conn *net.UDPConn, listener *Listener) error {
+	conn.SetReadBuffer(4194304)
+	conn.SetWriteBuffer(4194304)
+	localAddr, _ := conn.LocalAddr().(*net.UDPAddr)
 
 	var n int
+	var err error
 	for {
 
 		//new request
 		r := &Request{
 			Start:time.Now(),
 			Packet: &Packet{},
+			Listener:  listener,
+			LocalAddr: localAddr,
 		}
 		buff := make([]byte, MaxPacketLength)
-		n, r.RemoteAddr, err = s.connection.ReadFromUDP(buff)
+		n, r.RemoteAddr, err = conn.ReadFromUDP(buff)
+		if s.isClosed() {
+			return ErrServerClosed
+		}
 		if err != nil && !err.(*net.OpError).Temporary() {
 			break
 		}
@@ -93,24 +259,317 @@ func (s *Server) ListenAndServe() error {
 			continue
 		}
 		r.Packet.Wire = buff[:n]
+		s.tap(r.RemoteAddr, localAddr, r.Packet.Wire)
 
 		//try decode and check
 		if err := r.Packet.Decode(); err != nil {
 			l.Errorf(" packet decode: %v wire: %x", err, r.Packet.Wire)
+			s.count(r, statsMalformed)
+			continue
+		}
+		if _, known := packetName[r.Packet.Type]; !known {
+			l.Warnf(" packet unknown code %d from %s", r.Packet.Type, r.RemoteAddr)
+			s.count(r, statsUnknownType)
 			continue
 		}
-		//todo  goroutine counting
-		go s.Handler.ServeRequest(s.connection, r)
+		if !listener.allows(r.Packet.Type) {
+			l.Warnf(" packet %s is not allowed on %s from %s", r.Packet.Type, localAddr, r.RemoteAddr)
+			s.count(r, statsUnknownType)
+			continue
+		}
+		if s.Secrets != nil {
+			if err := authenticate(s.Secrets, r); err != nil {
+				l.Warnf(" packet authenticate: %v from %s", err, r.RemoteAddr)
+				switch {
+				case errors.Is(err, errUnknownClient):
+					s.count(r, statsInvalid)
+				case errors.Is(err, errBadAuthenticator):
+					s.count(r, statsBadAuthenticator)
+				default:
+					s.count(r, statsDropped)
+				}
+				continue
+			}
+		}
+		if r.Packet.Type == Code_StatusServer && s.Secrets != nil && s.StatusServer != StatusServerPolicy_Handler {
+			s.serveStatus(&udpResponseWriter{s: s, conn: conn, request: r})
+			continue
+		}
+		if s.Validation != ValidationPolicy_None {
+			if err := r.Packet.Validate(); err != nil {
+				l.Warnf(" packet validate: %v from %s", err, r.RemoteAddr)
+				if s.Validation == ValidationPolicy_Drop {
+					s.count(r, statsMalformed)
+					continue
+				}
+			}
+		}
+		s.count(r, statsRequest)
+		w := &udpResponseWriter{s: s, conn: conn, request: r}
+		if s.Duplicates != nil {
+			key := newDuplicateKey(r.RemoteAddr, r.Packet)
+			reply, duplicate, err := s.Duplicates.begin(key, r.Start)
+			if err != nil {
+				l.Warnf(" packet duplicates: %v from %s", err, r.RemoteAddr)
+				s.count(r, statsDropped)
+				continue
+			}
+			if duplicate {
+				s.count(r, statsDuplicate)
+				if reply != nil {
+					w.writeWire(reply)
+				}
+				continue
+			}
+			w.duplicateKey = &key
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return ErrServerClosed
+		}
+		s.handlers.Add(1)
+		s.mu.Unlock()
+		if s.queue == nil {
+			go s.serve(w, r)
+		} else if dropped := s.queue.push(w); dropped != nil {
+			s.drop(dropped)
+		}
+	}
+
+	s.Close()
+	return err
+}
+
+func (s *Server) serve(w *udpResponseWriter, r *Request) {
+	timeout := s.RequestTimeout
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+	defer s.handlers.Done()
+	ctx, cancel := context.WithDeadline(s.baseCtx, r.Start.Add(timeout))
+	defer cancel()
+	ctx = context.WithValue(ctx, writerContextKey{}, w)
+	s.Handler.ServeRADIUS(ctx, w, r)
+	if w.reply == nil && !w.legacy {
+		s.count(r, statsDropped)
+	}
+	if w.duplicateKey != nil && w.reply == nil {
+		// retransmission of unanswered request is served again
+		s.Duplicates.forget(*w.duplicateKey)
+	}
+}
+
+func (s *Server) worker() {
+	for w := s.queue.pop(); w != nil; w = s.queue.pop() {
+		s.serve(w, w.request)
+	}
+}
+
+// drop discards queued request, its retransmission is served as a new request
+func (s *Server) drop(w *udpResponseWriter) {
+	l.Warnf(" packet dropped: queue is full, %s from %s", w.request.Packet.Type, w.request.RemoteAddr)
+	s.count(w.request, statsDropped)
+	if w.duplicateKey != nil {
+		s.Duplicates.forget(*w.duplicateKey)
+	}
+	s.handlers.Done()
+}
+
+// count adds event of request to Stats, only Access-Request and Accounting-Request are counted
+// as requests, duplicates and dropped
+func (s *Server) count(r *Request, event statsEvent) {
+	if s.Stats == nil {
+		return
+	}
+	switch event {
+	case statsRequest, statsDuplicate, statsDropped:
+		if r.Packet.Type != Code_AccessRequest && r.Packet.Type != Code_AccountingRequest {
+			return
+		}
+	}
+	s.Stats.count(s.statsClient(r), acctServer(r), event)
+}
+
+// statsClient is key of request client in Stats, empty if client is unknown
+func (s *Server) statsClient(r *Request) string {
+	client := r.Client
+	if client == nil && s.Secrets != nil {
+		client, _ = s.Secrets.Lookup(r.RemoteAddr)
+	}
+	switch {
+	case client == nil:
+		return ""
+	case client.Name != "":
+		return client.Name
+	}
+	return r.RemoteAddr.IP.String()
+}
+
+// acctServer reports whether request is served by accounting server, packets of other codes
+// are served by accounting server on listeners of accounting only, or on accounting ports
+// 1813 and 1646 if the listener allows any code
+func acctServer(r *Request) bool {
+	switch r.Packet.Type {
+	case Code_AccountingRequest:
+		return true
+	case Code_AccessRequest:
+		return false
+	}
+	if r.Listener == nil || len(r.Listener.Codes) == 0 {
+		return r.LocalAddr != nil && (r.LocalAddr.Port == 1813 || r.LocalAddr.Port == 1646)
+	}
+	return r.Listener.allows(Code_AccountingRequest) && !r.Listener.allows(Code_AccessRequest)
+}
+
+// OverloadStats returns counters of requests dropped with MaxConcurrency
+func (s *Server) OverloadStats() OverloadStats {
+	s.mu.Lock()
+	q := s.queue
+	s.mu.Unlock()
+	if q == nil {
+		return OverloadStats{DroppedByCode: map[PacketType]uint64{}}
+	}
+	return q.stats()
+}
+
+type udpResponseWriter struct {
+	s       *Server
+	conn    *net.UDPConn
+	request *Request
+	// reply is wire of written reply
+	reply []byte
+	// duplicateKey is set if request is registered in Server.Duplicates
+	duplicateKey *duplicateKey
+	// legacy is set by Legacy handler which writes reply to conn
+	legacy bool
+}
+
+func (w *udpResponseWriter) Write(reply *Packet) error {
+	if w.reply != nil {
+		return errors.New("radius: reply is already written")
+	}
+	if err := prepareReply(reply, w.request); err != nil {
+		return err
+	}
+	// validated after Encode has added Message-Authenticator
+	if w.s.Validation != ValidationPolicy_None {
+		if err := reply.Validate(); err != nil {
+			l.Warnf(" packet validate reply: %v to %s", err, w.request.RemoteAddr)
+			if w.s.Validation == ValidationPolicy_Drop {
+				return fmt.Errorf("radius: invalid reply: %w", err)
+			}
+		}
+	}
+	if err := w.writeWire(reply.Wire); err != nil {
+		return err
+	}
+	w.reply = reply.Wire
+	if w.s.Stats != nil {
+		w.s.Stats.countReply(w.s.statsClient(w.request), reply.Type)
 	}
+	if w.duplicateKey != nil {
+		w.s.Duplicates.done(*w.duplicateKey, w.reply, time.Now())
+	}
+	return nil
+}
 
+func (w *udpResponseWriter) writeWire(wire []byte) error {
+	if _, err := w.conn.WriteToUDP(wire, w.request.RemoteAddr); err != nil {
+		return err
+	}
+	w.s.tap(w.request.LocalAddr, w.request.RemoteAddr, wire)
 	return nil
 }
 
+func (w *udpResponseWriter) LocalAddr() net.Addr {
+	return w.conn.LocalAddr()
+}
+
+func (w *udpResponseWriter) RemoteAddr() net.Addr {
+	return w.request.RemoteAddr
+}
+
+// prepareReply fills reply fields taken from request and encodes it
+func prepareReply(reply *Packet, r *Request) error {
+	request := r.Packet
+	reply.Identifier = request.Identifier
+	reply.RequestAuthenticator = request.Authenticator
+	if len(reply.Secret) == 0 {
+		reply.Secret = r.Secret
+		if len(reply.Secret) == 0 {
+			reply.Secret = request.Secret
+		}
+	}
+	if request.Attr(Attr_MessageAuthenticator) != nil || request.Attr(Attr_EAPMessage) != nil {
+		reply.SignMessageAuthenticator = true
+	}
+	return reply.Encode()
+}
+
+func (s *Server) tap(src, dst *net.UDPAddr, wire []byte) {
+	if s.Tap == nil {
+		return
+	}
+	if err := s.Tap.WriteDatagram(time.Now(), src, dst, wire); err != nil {
+		l.Errorf(" packet tap: %v", err)
+	}
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+/*
+Shutdown stops reading packets and waits for requests in flight to be served, then closes
+connections. If ctx is done first, handler contexts are canceled, connections are closed
+and ctx error is returned. ListenAndServe returns ErrServerClosed.
+*/
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	conns := s.connections
+	s.mu.Unlock()
+	if conns == nil {
+		return nil
+	}
+	// wakes up reading, connections stay open for replies in flight
+	for _, conn := range conns {
+		conn.SetReadDeadline(time.Now())
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		s.handlers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		s.Close()
+		return ctx.Err()
+	}
+	return s.Close()
+}
+
 // Close stops listening for packets. Any packet that is currently being
 // handled will not be able to respond to the sender.
 func (s *Server) Close() error {
-	if s.connection == nil {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.connections == nil {
 		return nil
 	}
-	return s.connection.Close()
+	s.cancelBase()
+	var err error
+	for _, conn := range s.connections {
+		if closeErr := conn.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	s.connections = nil
+	return err
 }
@@ -0,0 +1,163 @@
+package radius
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+// startServer starts s on free port of 127.0.0.1 until the test ends,
+// error of ListenAndServe is sent to the channel
+func startServer(t *testing.T, s *Server) (*net.UDPAddr, <-chan error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	conn.Close()
+
+	s.Addr = addr.String()
+	errs := make(chan error, 1)
+	go func() { errs <- s.ListenAndServe() }()
+	t.Cleanup(func() { s.Close() })
+
+	// wait for listener
+	for i := 0; i < 50; i++ {
+		c, err := net.ListenUDP("udp4", addr)
+		if err != nil {
+			return addr, errs
+		}
+		c.Close()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("server doesn't start")
+	return nil, nil
+}
+
+func TestServer_ResponseWriter(t *testing.T) {
+	secret := []byte("ctrhtn")
+	errs := make(chan error, 1)
+	s := &Server{
+		RequestTimeout: time.Minute,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			if deadline, ok := ctx.Deadline(); !ok || deadline.Sub(r.Start) != time.Minute {
+				t.Errorf("Expected deadline a minute after start got %s", deadline)
+			}
+			reply := &Packet{Type: Code_AccessAccept, Secret: secret}
+			reply.AddAttribute(Attr_ReplyMessage, "welcome")
+			if err := w.Write(reply); err != nil {
+				t.Error(err)
+			}
+			errs <- w.Write(reply)
+		}),
+	}
+	addr, _ := startServer(t, s)
+
+	request := NewPacket(Code_AccessRequest, secret)
+	request.AddAttribute(Attr_UserName, "bob")
+	request.SignMessageAuthenticator = true
+	reply, err := ExchangePacket(request, addr, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != Code_AccessAccept || reply.Attr(Attr_MessageAuthenticator) == nil {
+		t.Errorf("Expected Access-Accept with Message-Authenticator got %s", reply)
+	}
+	if err := <-errs; err == nil {
+		t.Error("Expected error of second reply")
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	secret := []byte("ctrhtn")
+	stats := &Stats{}
+	s := &Server{
+		Stats:      stats,
+		Duplicates: &DuplicateCache{},
+		Handler: Legacy(LegacyHandlerFunc(func(conn *net.UDPConn, r *Request) {
+			reply := &Packet{Type: Code_AccessAccept, Secret: secret, Identifier: r.Packet.Identifier,
+				RequestAuthenticator: r.Packet.Authenticator}
+			if err := reply.Encode(); err != nil {
+				t.Error(err)
+				return
+			}
+			conn.WriteToUDP(reply.Wire, r.RemoteAddr)
+		})),
+	}
+	addr, _ := startServer(t, s)
+	reply, err := ExchangePacket(NewPacket(Code_AccessRequest, secret), addr, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Type != Code_AccessAccept {
+		t.Errorf("Expected Access-Accept got %s", reply.Type)
+	}
+	// handler returns after the reply is sent
+	time.Sleep(50 * time.Millisecond)
+	if n := stats.Global().AuthPacketsDropped; n != 0 {
+		t.Errorf("Expected no dropped requests got %d", n)
+	}
+	if n := s.Duplicates.Len(); n != 0 {
+		t.Errorf("Expected no duplicate entries got %d", n)
+	}
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	secret := []byte("ctrhtn")
+	started := make(chan struct{}, 2)
+	canceled := make(chan error, 1)
+	s := &Server{
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			started <- struct{}{}
+			if r.Packet.Attr(Attr_UserName) != nil {
+				// stuck handler
+				<-ctx.Done()
+				canceled <- ctx.Err()
+				return
+			}
+			time.Sleep(100 * time.Millisecond)
+			w.Write(&Packet{Type: Code_AccountingResponse, Secret: secret})
+		}),
+	}
+	addr, errs := startServer(t, s)
+
+	// reply of request in flight is written after Shutdown starts
+	replies := make(chan error, 1)
+	go func() {
+		_, err := ExchangePacket(NewPacket(Code_AccountingRequest, secret), addr, 1, time.Second)
+		replies <- err
+	}()
+	<-started
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-replies; err != nil {
+		t.Errorf("Expected reply of request in flight got %v", err)
+	}
+	if err := <-errs; err != ErrServerClosed {
+		t.Errorf("Expected ErrServerClosed got %v", err)
+	}
+	if err := s.ListenAndServe(); err != ErrServerClosed {
+		t.Errorf("Expected ErrServerClosed of closed server got %v", err)
+	}
+
+	// handlers of server which doesn't drain in time are canceled
+	s = &Server{Handler: s.Handler}
+	addr, errs = startServer(t, s)
+	request := NewPacket(Code_AccountingRequest, secret)
+	request.AddAttribute(Attr_UserName, "stuck")
+	go ExchangePacket(request, addr, 1, time.Second)
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected %v got %v", context.DeadlineExceeded, err)
+	}
+	if err := <-canceled; err != context.Canceled {
+		t.Errorf("Expected handler context to be canceled got %v", err)
+	}
+	if err := <-errs; err != ErrServerClosed {
+		t.Errorf("Expected ErrServerClosed got %v", err)
+	}
+}
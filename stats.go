@@ -0,0 +1,204 @@
+package radius
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+Counters are counters of RADIUS authentication server MIB, rfc 4669, and accounting server MIB,
+rfc 4671. InvalidRequests of requests from unknown clients are counted only in global counters.
+*/
+type Counters struct {
+	AccessRequests          uint64
+	AuthInvalidRequests     uint64
+	DupAccessRequests       uint64
+	AccessAccepts           uint64
+	AccessRejects           uint64
+	AccessChallenges        uint64
+	MalformedAccessRequests uint64
+	AuthBadAuthenticators   uint64
+	AuthPacketsDropped      uint64
+	AuthUnknownTypes        uint64
+
+	AccountingRequests          uint64
+	AcctInvalidRequests         uint64
+	DupAccountingRequests       uint64
+	AccountingResponses         uint64
+	MalformedAccountingRequests uint64
+	AcctBadAuthenticators       uint64
+	AcctPacketsDropped          uint64
+	AcctUnknownTypes            uint64
+}
+
+/*
+Stats are global and per client Counters filled by Server. Clients are known only with
+Server.Secrets and they are keyed by Client.Name, or source IP if the name is empty.
+Stats is http.Handler of Prometheus text exposition:
+
+	stats := &radius.Stats{}
+	s := &radius.Server{Handler: handler, Secrets: clients, Stats: stats}
+	http.Handle("/metrics", stats)
+*/
+type Stats struct {
+	mu      sync.Mutex
+	global  Counters
+	clients map[string]*Counters
+}
+
+// statsEvent is what happened to request
+type statsEvent int
+
+const (
+	statsRequest statsEvent = iota
+	statsInvalid
+	statsDuplicate
+	statsMalformed
+	statsBadAuthenticator
+	statsDropped
+	statsUnknownType
+)
+
+// counter of event in auth or acct server group
+func (c *Counters) counter(acct bool, event statsEvent) *uint64 {
+	if acct {
+		return [...]*uint64{&c.AccountingRequests, &c.AcctInvalidRequests, &c.DupAccountingRequests,
+			&c.MalformedAccountingRequests, &c.AcctBadAuthenticators, &c.AcctPacketsDropped, &c.AcctUnknownTypes}[event]
+	}
+	return [...]*uint64{&c.AccessRequests, &c.AuthInvalidRequests, &c.DupAccessRequests,
+		&c.MalformedAccessRequests, &c.AuthBadAuthenticators, &c.AuthPacketsDropped, &c.AuthUnknownTypes}[event]
+}
+
+// replyCounter is counter of reply code, nil if it isn't counted
+func (c *Counters) replyCounter(code PacketType) *uint64 {
+	switch code {
+	case Code_AccessAccept:
+		return &c.AccessAccepts
+	case Code_AccessReject:
+		return &c.AccessRejects
+	case Code_AccessChallenge:
+		return &c.AccessChallenges
+	case Code_AccountingResponse:
+		return &c.AccountingResponses
+	}
+	return nil
+}
+
+// count adds event to global counters and counters of client, empty client is unknown
+func (s *Stats) count(client string, acct bool, event statsEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.global.counter(acct, event)++
+	if client != "" && event != statsInvalid {
+		*s.client(client).counter(acct, event)++
+	}
+}
+
+// countReply adds reply sent to client
+func (s *Stats) countReply(client string, code PacketType) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if counter := s.global.replyCounter(code); counter != nil {
+		*counter++
+		if client != "" {
+			*s.client(client).replyCounter(code)++
+		}
+	}
+}
+
+func (s *Stats) client(name string) *Counters {
+	if s.clients == nil {
+		s.clients = make(map[string]*Counters)
+	}
+	c, ok := s.clients[name]
+	if !ok {
+		c = &Counters{}
+		s.clients[name] = c
+	}
+	return c
+}
+
+// Global returns counters of all requests
+func (s *Stats) Global() Counters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.global
+}
+
+// Clients returns counters by client
+func (s *Stats) Clients() map[string]Counters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clients := make(map[string]Counters, len(s.clients))
+	for name, c := range s.clients {
+		clients[name] = *c
+	}
+	return clients
+}
+
+// statsMetrics are Prometheus names of Counters, global metrics are prefixed with radius_ and
+// client metrics with radius_client_
+var statsMetrics = []struct {
+	name, help string
+	value      func(c *Counters) uint64
+	globalOnly bool
+}{
+	{"auth_access_requests_total", "Access-Request packets received, radiusAuthServTotalAccessRequests.", func(c *Counters) uint64 { return c.AccessRequests }, false},
+	{"auth_invalid_requests_total", "Packets received from unknown addresses on authentication port, radiusAuthServTotalInvalidRequests.", func(c *Counters) uint64 { return c.AuthInvalidRequests }, true},
+	{"auth_dup_access_requests_total", "Duplicate Access-Request packets received, radiusAuthServTotalDupAccessRequests.", func(c *Counters) uint64 { return c.DupAccessRequests }, false},
+	{"auth_access_accepts_total", "Access-Accept packets sent, radiusAuthServTotalAccessAccepts.", func(c *Counters) uint64 { return c.AccessAccepts }, false},
+	{"auth_access_rejects_total", "Access-Reject packets sent, radiusAuthServTotalAccessRejects.", func(c *Counters) uint64 { return c.AccessRejects }, false},
+	{"auth_access_challenges_total", "Access-Challenge packets sent, radiusAuthServTotalAccessChallenges.", func(c *Counters) uint64 { return c.AccessChallenges }, false},
+	{"auth_malformed_access_requests_total", "Malformed packets received on authentication port, radiusAuthServTotalMalformedAccessRequests.", func(c *Counters) uint64 { return c.MalformedAccessRequests }, false},
+	{"auth_bad_authenticators_total", "Packets with invalid authenticator received on authentication port, radiusAuthServTotalBadAuthenticators.", func(c *Counters) uint64 { return c.AuthBadAuthenticators }, false},
+	{"auth_packets_dropped_total", "Packets dropped on authentication port for other reasons, radiusAuthServTotalPacketsDropped.", func(c *Counters) uint64 { return c.AuthPacketsDropped }, false},
+	{"auth_unknown_types_total", "Packets of unknown type received on authentication port, radiusAuthServTotalUnknownTypes.", func(c *Counters) uint64 { return c.AuthUnknownTypes }, false},
+	{"acct_requests_total", "Accounting-Request packets received, radiusAccServTotalRequests.", func(c *Counters) uint64 { return c.AccountingRequests }, false},
+	{"acct_invalid_requests_total", "Packets received from unknown addresses on accounting port, radiusAccServTotalInvalidRequests.", func(c *Counters) uint64 { return c.AcctInvalidRequests }, true},
+	{"acct_dup_requests_total", "Duplicate Accounting-Request packets received, radiusAccServTotalDupRequests.", func(c *Counters) uint64 { return c.DupAccountingRequests }, false},
+	{"acct_responses_total", "Accounting-Response packets sent, radiusAccServTotalResponses.", func(c *Counters) uint64 { return c.AccountingResponses }, false},
+	{"acct_malformed_requests_total", "Malformed packets received on accounting port, radiusAccServTotalMalformedRequests.", func(c *Counters) uint64 { return c.MalformedAccountingRequests }, false},
+	{"acct_bad_authenticators_total", "Packets with invalid authenticator received on accounting port, radiusAccServTotalBadAuthenticators.", func(c *Counters) uint64 { return c.AcctBadAuthenticators }, false},
+	{"acct_packets_dropped_total", "Packets dropped on accounting port for other reasons, radiusAccServTotalPacketsDropped.", func(c *Counters) uint64 { return c.AcctPacketsDropped }, false},
+	{"acct_unknown_types_total", "Packets of unknown type received on accounting port, radiusAccServTotalUnknownTypes.", func(c *Counters) uint64 { return c.AcctUnknownTypes }, false},
+}
+
+// ServeHTTP writes counters in Prometheus text format
+func (s *Stats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	s.WriteTo(w)
+}
+
+// WriteTo writes counters in Prometheus text format
+func (s *Stats) WriteTo(w io.Writer) (int64, error) {
+	global := s.Global()
+	clients := s.Clients()
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	b := &strings.Builder{}
+	for _, m := range statsMetrics {
+		fmt.Fprintf(b, "# HELP radius_%s %s\n# TYPE radius_%s counter\nradius_%s %d\n", m.name, m.help, m.name, m.name, m.value(&global))
+	}
+	for _, m := range statsMetrics {
+		if m.globalOnly || len(names) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "# HELP radius_client_%s %s\n# TYPE radius_client_%s counter\n", m.name, m.help, m.name)
+		for _, name := range names {
+			c := clients[name]
+			fmt.Fprintf(b, "radius_client_%s{client=\"%s\"} %d\n", m.name, labelEscaper.Replace(name), m.value(&c))
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
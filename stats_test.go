@@ -0,0 +1,142 @@
+package radius
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_Stats(t *testing.T) {
+	secret := []byte("ctrhtn")
+	clients := &ClientTable{}
+	clients.Add("127.0.0.1", &Client{Name: "local", Secret: secret})
+	stats := &Stats{}
+	s := &Server{
+		Secrets:    clients,
+		Stats:      stats,
+		Duplicates: &DuplicateCache{},
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			switch {
+			case r.Packet.Type == Code_AccountingRequest:
+				w.Write(&Packet{Type: Code_AccountingResponse})
+			case r.Packet.Attr(Attr_UserName).Value == "reject":
+				w.Write(&Packet{Type: Code_AccessReject})
+			case r.Packet.Attr(Attr_UserName).Value == "drop":
+			default:
+				w.Write(&Packet{Type: Code_AccessAccept})
+			}
+		}),
+	}
+	addr, _ := startServer(t, s)
+	conn, err := net.DialUDP("udp4", nil, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	send := func(p *Packet) {
+		if err := p.Encode(); err != nil {
+			t.Fatal(err)
+		}
+		conn.Write(p.Wire)
+	}
+	accessRequest := func(userName string, secret []byte) *Packet {
+		p := NewPacket(Code_AccessRequest, secret)
+		p.AddAttribute(Attr_UserName, userName)
+		p.SignMessageAuthenticator = true
+		return p
+	}
+
+	accept := accessRequest("bob", secret)
+	send(accept)
+	conn.Write(accept.Wire)
+	send(accessRequest("reject", secret))
+	send(accessRequest("drop", secret))
+	send(accessRequest("bob", []byte("wrong")))
+	send(NewPacket(Code_AccountingRequest, secret))
+	send(NewPacket(Code_AccountingRequest, []byte("wrong")))
+	conn.Write([]byte{byte(Code_AccessRequest), 1, 0})
+	conn.Write(append([]byte{99, 1, 0, 20}, make([]byte, 16)...))
+
+	// request of unknown client
+	unknown, err := net.DialUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 2)}, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unknown.Close()
+	p := accessRequest("bob", secret)
+	p.Encode()
+	unknown.Write(p.Wire)
+	time.Sleep(100 * time.Millisecond)
+
+	global := stats.Global()
+	want := Counters{
+		AccessRequests:          4,
+		AuthInvalidRequests:     1,
+		DupAccessRequests:       1,
+		AccessAccepts:           1,
+		AccessRejects:           1,
+		MalformedAccessRequests: 1,
+		AuthBadAuthenticators:   1,
+		AuthPacketsDropped:      1,
+		AuthUnknownTypes:        1,
+		AccountingRequests:      1,
+		AccountingResponses:     1,
+		AcctBadAuthenticators:   1,
+	}
+	if global != want {
+		t.Errorf("Expected %+v got %+v", want, global)
+	}
+	// malformed packet and unknown type come from the known client
+	want.AuthInvalidRequests = 0
+	if local := stats.Clients()["local"]; local != want {
+		t.Errorf("Expected %+v of client got %+v", want, local)
+	}
+
+	rec := httptest.NewRecorder()
+	stats.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE radius_auth_bad_authenticators_total counter",
+		"radius_auth_bad_authenticators_total 1",
+		"radius_auth_invalid_requests_total 1",
+		`radius_client_acct_bad_authenticators_total{client="local"} 1`,
+		`radius_client_auth_access_requests_total{client="local"} 4`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected %q in\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, "radius_client_auth_invalid_requests_total") {
+		t.Error("Expected invalid requests to be global only")
+	}
+}
+
+func TestServer_StatsTruncated(t *testing.T) {
+	secret := []byte("ctrhtn")
+	stats := &Stats{}
+	s := &Server{
+		Stats: stats,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			w.Write(&Packet{Type: Code_AccessAccept, Secret: secret})
+		}),
+	}
+	addr, _ := startServer(t, s)
+	conn, err := net.DialUDP("udp4", nil, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// length field says 40 bytes
+	conn.Write([]byte{byte(Code_AccessRequest), 2, 0, 40, 0, 0})
+
+	// server survives the datagram
+	if _, err := ExchangePacket(NewPacket(Code_AccessRequest, secret), addr, 1, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if n := stats.Global().MalformedAccessRequests; n != 1 {
+		t.Errorf("Expected 1 malformed request got %d", n)
+	}
+}
@@ -0,0 +1,514 @@
+package radius
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+	Text syntax of radclient and FreeRADIUS configs, pairs are separated by new lines or commas:
+
+	User-Name = "bob"
+	Framed-IP-Address = 10.0.0.1
+	Cisco-AVPair += "ip:addr-pool=x"
+	Tunnel-Type:1 = VLAN, Tunnel-Medium-Type:1 = IEEE-802
+	Class = 0x00ff
+	Attr-242 = 0x0102          # unknown attribute
+	Attr-26.9.200 = 0x0102     # unknown vendor attribute
+
+	Operators = and += append the attribute, := replaces previous attributes of the name.
+	Integers are value names or numbers, dates are rfc 3339, FreeRADIUS "Jan 2 2006 15:04:05 UTC"
+	or seconds, octets are 0x hex or quoted strings. Every vendor attribute is placed in its own
+	Vendor-Specific attribute.
+*/
+
+const (
+	dateLayoutFreeRADIUS = "Jan _2 2006 15:04:05 MST"
+)
+
+// textName is attribute or vendor attribute resolved from text name
+type textName struct {
+	attrType   AttributeType
+	vendor     bool
+	vendorId   uint32
+	vendorType uint8
+	tag        uint8
+}
+
+// ParseAttributes parses attributes in text syntax of radclient
+func ParseAttributes(text string) ([]*Attribute, error) {
+	s := &textScanner{text: text, line: 1}
+	var attrs []*Attribute
+	for {
+		s.skipSpace(true)
+		if s.eof() {
+			return attrs, nil
+		}
+		name, op, value, err := s.pair()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", s.line, err)
+		}
+		tn, err := parseTextName(name)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", s.line, err)
+		}
+		a, err := tn.attribute(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %v", s.line, name, err)
+		}
+		if op == ":=" {
+			attrs = tn.remove(attrs)
+		}
+		attrs = append(attrs, a)
+	}
+}
+
+// FormatAttributes prints attributes one per line in syntax accepted by ParseAttributes
+func FormatAttributes(attrs []*Attribute) string {
+	var b strings.Builder
+	for _, a := range attrs {
+		if a.Type != Attr_VendorSpecific {
+			fmt.Fprintf(&b, "%s = %s\n", textTaggedName(a), textValue(a.Value, attrValueWire(a), attrTypeToValues[a.Type]))
+			continue
+		}
+		vendorId, _ := a.VendorId()
+		for _, vsa := range a.Pairs {
+			fmt.Fprintf(&b, "%s = %s\n", textVSAName(vendorId, vsa.VendorType), textValue(vsa.Data, vsa.Value, vsaTypeToValues[vendorId][vsa.VendorType]))
+		}
+	}
+	return b.String()
+}
+
+func textAttrName(t AttributeType) string {
+	if name := t.Name(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("Attr-%d", t)
+}
+
+// textVSAName is dictionary name of vendor attribute or Attr-26.Vendor.N
+func textVSAName(vendorId uint32, vendorType uint8) string {
+	if name := VSAName(vendorId, vendorType); name != "" {
+		return name
+	}
+	return fmt.Sprintf("Attr-%d.%d.%d", Attr_VendorSpecific, vendorId, vendorType)
+}
+
+// textTaggedName is name of attribute with tag of tagged attribute as Name:tag
+func textTaggedName(a *Attribute) string {
+	if attrTypeToInfo[a.Type].Flags.Tagged && a.Tag != 0 {
+		return textAttrName(a.Type) + ":" + strconv.Itoa(int(a.Tag))
+	}
+	return textAttrName(a.Type)
+}
+
+// textValue formats value as radclient does, values which can't be typed are hex of the wire
+func textValue(value interface{}, wire []byte, values *attrValues) string {
+	switch v := value.(type) {
+	case string:
+		if quoted, ok := quoteText(v); ok {
+			return quoted
+		}
+	case uint32, uint64:
+		return fmt.Sprint(v)
+	case EnumValue:
+		if values != nil {
+			if name, ok := values.names[v.Uint32()]; ok {
+				return name
+			}
+		}
+		return strconv.FormatUint(uint64(v.Uint32()), 10)
+	case AttributeValue:
+		return strconv.FormatUint(uint64(v), 10)
+	case net.IP:
+		return v.String()
+	case *net.IPNet:
+		return v.String()
+	case time.Time:
+		return `"` + v.UTC().Format(time.RFC3339) + `"`
+	}
+	return "0x" + hex.EncodeToString(wire)
+}
+
+// quoteText quotes printable string, ok is false for binary strings
+func quoteText(s string) (string, bool) {
+	if !utf8.ValidString(s) {
+		return "", false
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < ' ' || r == 0x7f {
+				return "", false
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String(), true
+}
+
+func parseTextName(name string) (tn textName, err error) {
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		tag, err := strconv.ParseUint(name[i+1:], 10, 8)
+		if err != nil || tag > 0x1f {
+			return tn, fmt.Errorf("invalid tag of %s", name)
+		}
+		tn.tag = uint8(tag)
+		name = name[:i]
+	}
+
+	if t, ok := AttributeTypeByName(name); ok {
+		tn.attrType = t
+		return tn, tn.checkTag(name)
+	}
+	for vendorId, names := range vsaNameToType {
+		if vendorType, ok := names[name]; ok {
+			tn.attrType = Attr_VendorSpecific
+			tn.vendor, tn.vendorId, tn.vendorType = true, vendorId, vendorType
+			return tn, tn.checkTag(name)
+		}
+	}
+
+	// Attr-N and Attr-26.Vendor.N of attributes unknown to dictionary
+	if !strings.HasPrefix(name, "Attr-") {
+		return tn, fmt.Errorf("unknown attribute %s", name)
+	}
+	parts := strings.Split(strings.TrimPrefix(name, "Attr-"), ".")
+	nums := make([]uint64, len(parts))
+	for i, part := range parts {
+		if nums[i], err = strconv.ParseUint(part, 10, 32); err != nil {
+			return tn, fmt.Errorf("invalid attribute %s", name)
+		}
+	}
+	switch {
+	case len(nums) == 1 && nums[0] > 0 && nums[0] <= 0xff:
+		tn.attrType = AttributeType(nums[0])
+	case len(nums) == 3 && nums[0] == uint64(Attr_VendorSpecific) && nums[2] <= 0xff:
+		tn.attrType = Attr_VendorSpecific
+		tn.vendor, tn.vendorId, tn.vendorType = true, uint32(nums[1]), uint8(nums[2])
+	default:
+		return tn, fmt.Errorf("invalid attribute %s", name)
+	}
+	return tn, tn.checkTag(name)
+}
+
+func (tn textName) checkTag(name string) error {
+	if tn.tag != 0 && (tn.vendor || !attrTypeToInfo[tn.attrType].Flags.Tagged) {
+		return fmt.Errorf("%s is not tagged", name)
+	}
+	return nil
+}
+
+func (tn textName) attribute(value textToken) (*Attribute, error) {
+	if tn.vendor {
+		a, err := NewVendorAttribute(tn.vendorId)
+		if err != nil {
+			return nil, err
+		}
+		vsa := &VSA{VendorType: tn.vendorType}
+		ai, known := lookupVSA(tn.vendorId, tn.vendorType)
+		if !known || ai.DataType == DataType_Octets {
+			// hex keeps values of structured vendor attributes as well
+			if vsa.Value, err = value.bytes(); err != nil {
+				return nil, err
+			}
+		} else {
+			if vsa.Data, err = parseTextValue(ai.DataType, vsaTypeToValues[tn.vendorId][tn.vendorType], value); err != nil {
+				return nil, err
+			}
+			if err = ai.Encoder.Encode(vsa); err != nil {
+				return nil, err
+			}
+		}
+		if known {
+			if err = ai.Encoder.Decode(vsa); err != nil {
+				return nil, err
+			}
+		}
+		a.Pairs = append(a.Pairs, vsa)
+		return a, nil
+	}
+
+	a := &Attribute{Type: tn.attrType, Tag: tn.tag, Encoder: DefaultEncoder}
+	ai, known := attrTypeToInfo[tn.attrType]
+	var err error
+	switch {
+	case !known:
+		a.Value, err = value.bytes()
+	case ai.DataType == DataType_VSA:
+		return nil, errors.New("vendor attributes are set by their names")
+	case ai.Flags.Encrypt != 0 && ai.Flags.Tagged:
+		a.Encoder = ai.Encoder
+		a.Value, err = value.bytes()
+	default:
+		a.Encoder = ai.Encoder
+		a.Value, err = parseTextValue(ai.DataType, attrTypeToValues[tn.attrType], value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = a.Encode(); err != nil {
+		return nil, err
+	}
+	// make value the same as decoded one
+	if err = a.Decode(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// remove drops attributes of the name from attrs for := operator
+func (tn textName) remove(attrs []*Attribute) []*Attribute {
+	out := attrs[:0]
+	for _, a := range attrs {
+		if a.Type != tn.attrType {
+			out = append(out, a)
+			continue
+		}
+		if !tn.vendor {
+			continue
+		}
+		if vendorId, _ := a.VendorId(); vendorId != tn.vendorId {
+			out = append(out, a)
+			continue
+		}
+		pairs := a.Pairs[:0]
+		for _, vsa := range a.Pairs {
+			if vsa.VendorType != tn.vendorType {
+				pairs = append(pairs, vsa)
+			}
+		}
+		if a.Pairs = pairs; len(pairs) > 0 {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// parseTextValue makes attribute value of the dictionary type
+func parseTextValue(dataType DataType, values *attrValues, value textToken) (interface{}, error) {
+	s := value.text
+	switch dataType {
+	case DataType_String:
+		if value.isHex() {
+			b, err := value.bytes()
+			return string(b), err
+		}
+		return s, nil
+	case DataType_Octets, DataType_IfId:
+		return value.bytes()
+	case DataType_Integer:
+		if v, ok := values.lookup(s); ok {
+			return v, nil
+		}
+		v, err := strconv.ParseUint(s, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q", s)
+		}
+		return uint32(v), nil
+	case DataType_Integer64:
+		v, err := strconv.ParseUint(s, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer64 %q", s)
+		}
+		return v, nil
+	case DataType_Date:
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(dateLayoutFreeRADIUS, s); err == nil {
+			return t, nil
+		}
+		seconds, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", s)
+		}
+		return time.Unix(int64(seconds), 0).UTC(), nil
+	case DataType_IPAddr:
+		ip := net.ParseIP(s).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", s)
+		}
+		return ip, nil
+	case DataType_IPv6Addr:
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv6 address %q", s)
+		}
+		return ip, nil
+	case DataType_IPv6Prefix:
+		_, prefix, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IPv6 prefix %q", s)
+		}
+		return prefix, nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", dataType)
+}
+
+func (av *attrValues) lookup(name string) (uint32, bool) {
+	if av == nil {
+		return 0, false
+	}
+	v, ok := av.values[name]
+	return v, ok
+}
+
+// textToken is a value, quoted string is never treated as hex
+type textToken struct {
+	text   string
+	quoted bool
+}
+
+func (t textToken) isHex() bool {
+	return !t.quoted && strings.HasPrefix(t.text, "0x")
+}
+
+func (t textToken) bytes() ([]byte, error) {
+	if !t.isHex() {
+		return []byte(t.text), nil
+	}
+	b, err := hex.DecodeString(t.text[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex %q", t.text)
+	}
+	return b, nil
+}
+
+type textScanner struct {
+	text string
+	pos  int
+	line int
+}
+
+func (s *textScanner) eof() bool {
+	return s.pos >= len(s.text)
+}
+
+// skipSpace skips blanks and comments, separators are skipped between pairs only
+func (s *textScanner) skipSpace(separators bool) {
+	for !s.eof() {
+		switch c := s.text[s.pos]; {
+		case c == ' ' || c == '\t' || c == '\r':
+		case c == '\n' && separators:
+			s.line++
+		case c == ',' && separators:
+		case c == '#':
+			for !s.eof() && s.text[s.pos] != '\n' {
+				s.pos++
+			}
+			continue
+		default:
+			return
+		}
+		s.pos++
+	}
+}
+
+func (s *textScanner) pair() (name, op string, value textToken, err error) {
+	start := s.pos
+	for !s.eof() && isNameByte(s.text[s.pos]) {
+		s.pos++
+	}
+	if s.pos > start && s.text[s.pos-1] == ':' && strings.HasPrefix(s.text[s.pos:], "=") {
+		// Name:= is the name followed by :=
+		s.pos--
+	}
+	if name = s.text[start:s.pos]; name == "" {
+		return "", "", value, fmt.Errorf("expected attribute name at %q", s.rest())
+	}
+	s.skipSpace(false)
+	for _, o := range []string{":=", "+=", "="} {
+		if strings.HasPrefix(s.text[s.pos:], o) {
+			op = o
+			break
+		}
+	}
+	if op == "" {
+		return "", "", value, fmt.Errorf("expected operator after %s at %q", name, s.rest())
+	}
+	s.pos += len(op)
+	s.skipSpace(false)
+	if value, err = s.value(); err != nil {
+		return "", "", value, fmt.Errorf("%s: %v", name, err)
+	}
+	s.skipSpace(false)
+	if !s.eof() && s.text[s.pos] != '\n' && s.text[s.pos] != ',' {
+		return "", "", value, fmt.Errorf("unexpected %q after %s", s.rest(), name)
+	}
+	return name, op, value, nil
+}
+
+func isNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == ':'
+}
+
+func (s *textScanner) value() (textToken, error) {
+	if s.eof() {
+		return textToken{}, errors.New("expected value")
+	}
+	if q := s.text[s.pos]; q == '"' || q == '\'' {
+		return s.quoted(q)
+	}
+	start := s.pos
+	for !s.eof() && !strings.ContainsRune(" \t\r\n,#", rune(s.text[s.pos])) {
+		s.pos++
+	}
+	return textToken{text: s.text[start:s.pos]}, nil
+}
+
+func (s *textScanner) quoted(q byte) (textToken, error) {
+	var b strings.Builder
+	for s.pos++; !s.eof(); s.pos++ {
+		c := s.text[s.pos]
+		switch {
+		case c == q:
+			s.pos++
+			return textToken{text: b.String(), quoted: true}, nil
+		case c == '\n':
+			return textToken{}, errors.New("unterminated string")
+		case c == '\\' && s.pos+1 < len(s.text):
+			s.pos++
+			switch e := s.text[s.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return textToken{}, errors.New("unterminated string")
+}
+
+func (s *textScanner) rest() string {
+	rest := s.text[s.pos:]
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
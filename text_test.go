@@ -0,0 +1,78 @@
+package radius
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParseAttributes(t *testing.T) {
+	attrs, err := ParseAttributes(`
+		User-Name = "bob \"the\" builder"   # comment
+		Framed-IP-Address = 10.0.0.1
+		Cisco-AVPair = "ip:vrf-id=internet"
+		Cisco-AVPair += "ip:addr-pool=x"
+		Tunnel-Type:1 = VLAN, Tunnel-Medium-Type:1 = IEEE-802
+		Session-Timeout = 60
+		Session-Timeout := 3600
+		Class = 0x00ff
+		Event-Timestamp = "2018-11-22T00:00:00Z"
+		Attr-242 = 0x0102
+		Attr-26.9.200 = 0x0304
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 11 {
+		t.Fatalf("Expected 11 attributes got %d", len(attrs))
+	}
+
+	p := &Packet{Attributes: attrs}
+	var name string
+	if err = p.Attr(Attr_UserName).ValueString(&name); err != nil || name != `bob "the" builder` {
+		t.Errorf("Expected bob \"the\" builder got %q", name)
+	}
+	if ip, _ := p.Attr(Attr_FramedIPAddress).Value.(net.IP); !ip.Equal(net.IPv4(10, 0, 0, 1)) {
+		t.Errorf("Expected 10.0.0.1 got %v", ip)
+	}
+	if avpairs := p.VSAs(Vendor_Cisco, VSA_CiscoAVPair); len(avpairs) != 2 || avpairs[1].Data != "ip:addr-pool=x" {
+		t.Errorf("Unexpected Cisco-AVPair %v", avpairs)
+	}
+	if a := p.Attr(Attr_TunnelType); a.Tag != 1 || a.Value != TunnelType_VLAN {
+		t.Errorf("Expected VLAN with tag 1 got %v with tag %d", a.Value, a.Tag)
+	}
+	if timeouts := p.Attrs(Attr_SessionTimeout); len(timeouts) != 1 || timeouts[0].Value != uint32(3600) {
+		t.Errorf("Expected single Session-Timeout 3600")
+	}
+
+	text := FormatAttributes(attrs)
+	for _, s := range []string{`User-Name = "bob \"the\" builder"`, "Tunnel-Type:1 = VLAN", "Class = 0x00ff",
+		`Cisco-AVPair = "ip:addr-pool=x"`, "Attr-242 = 0x0102", "Attr-26.9.200 = 0x0304"} {
+		if !strings.Contains(text, s+"\n") {
+			t.Errorf("Expected %s in\n%s", s, text)
+		}
+	}
+	again, err := ParseAttributes(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if FormatAttributes(again) != text {
+		t.Errorf("Expected\n%s\ngot\n%s", text, FormatAttributes(again))
+	}
+}
+
+func TestParseAttributes_Errors(t *testing.T) {
+	for _, text := range []string{
+		`Foo-Bar = 1`,
+		`User-Name "bob"`,
+		`User-Name:1 = "bob"`,
+		`Framed-IP-Address = bob`,
+		`Service-Type = Foo`,
+		`User-Name = "bob`,
+		`Session-Timeout = 1 2`,
+	} {
+		if _, err := ParseAttributes(text); err == nil {
+			t.Errorf("Expected error of %s", text)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package radius
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 3GPP TS 29.061 section 16.4.7 vendor specific attributes
+const (
+	Vendor_3GPP uint32 = 10415
+
+	VSA_3GPPIMSI                     uint8 = 1
+	VSA_3GPPChargingID               uint8 = 2
+	VSA_3GPPPDPType                  uint8 = 3
+	VSA_3GPPCGAddress                uint8 = 4
+	VSA_3GPPGPRSNegotiatedQoSProfile uint8 = 5
+	VSA_3GPPSGSNAddress              uint8 = 6
+	VSA_3GPPGGSNAddress              uint8 = 7
+	VSA_3GPPIMSIMCCMNC               uint8 = 8
+	VSA_3GPPGGSNMCCMNC               uint8 = 9
+	VSA_3GPPNSAPI                    uint8 = 10
+	VSA_3GPPSessionStopIndicator     uint8 = 11
+	VSA_3GPPSelectionMode            uint8 = 12
+	VSA_3GPPChargingCharacteristics  uint8 = 13
+	VSA_3GPPCGIPv6Address            uint8 = 14
+	VSA_3GPPSGSNIPv6Address          uint8 = 15
+	VSA_3GPPGGSNIPv6Address          uint8 = 16
+	VSA_3GPPIPv6DNSServers           uint8 = 17
+	VSA_3GPPSGSNMCCMNC               uint8 = 18
+	VSA_3GPPTeardownIndicator        uint8 = 19
+	VSA_3GPPIMEISV                   uint8 = 20
+	VSA_3GPPRATType                  uint8 = 21
+	VSA_3GPPUserLocationInfo         uint8 = 22
+	VSA_3GPPMSTimeZone               uint8 = 23
+	VSA_3GPPCAMELChargingInfo        uint8 = 24
+	VSA_3GPPPacketFilter             uint8 = 25
+	VSA_3GPPNegotiatedDSCP           uint8 = 26
+	VSA_3GPPAllocateIPType           uint8 = 27
+)
+
+func init() {
+	strEncoder := &VSAEncoderString{}
+	octetsEncoder := &VSAEncoderOctets{}
+	addrEncoder := &VSAEncoderAddress{}
+	ipv6AddrEncoder := &VSAEncoderIPv6Address{}
+	uint32Encoder := &VSAEncoderUint32{}
+
+	registerVendor(Vendor_3GPP, "3GPP")
+
+	registerVSA(Vendor_3GPP, VSA_3GPPIMSI, "3GPP-IMSI", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPChargingID, "3GPP-Charging-ID", DataType_Integer, uint32Encoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPPDPType, "3GPP-PDP-Type", DataType_Integer, uint32Encoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPCGAddress, "3GPP-CG-Address", DataType_IPAddr, addrEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPGPRSNegotiatedQoSProfile, "3GPP-GPRS-Negotiated-QoS-Profile", DataType_Octets, &VSAEncoder3GPPQoSProfile{})
+	registerVSA(Vendor_3GPP, VSA_3GPPSGSNAddress, "3GPP-SGSN-Address", DataType_IPAddr, addrEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPGGSNAddress, "3GPP-GGSN-Address", DataType_IPAddr, addrEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPIMSIMCCMNC, "3GPP-IMSI-MCC-MNC", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPGGSNMCCMNC, "3GPP-GGSN-MCC-MNC", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPNSAPI, "3GPP-NSAPI", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPSessionStopIndicator, "3GPP-Session-Stop-Indicator", DataType_Octets, octetsEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPSelectionMode, "3GPP-Selection-Mode", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPChargingCharacteristics, "3GPP-Charging-Characteristics", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPCGIPv6Address, "3GPP-CG-IPv6-Address", DataType_IPv6Addr, ipv6AddrEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPSGSNIPv6Address, "3GPP-SGSN-IPv6-Address", DataType_IPv6Addr, ipv6AddrEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPGGSNIPv6Address, "3GPP-GGSN-IPv6-Address", DataType_IPv6Addr, ipv6AddrEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPIPv6DNSServers, "3GPP-IPv6-DNS-Servers", DataType_Octets, octetsEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPSGSNMCCMNC, "3GPP-SGSN-MCC-MNC", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPTeardownIndicator, "3GPP-Teardown-Indicator", DataType_Octets, octetsEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPIMEISV, "3GPP-IMEISV", DataType_String, strEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPRATType, "3GPP-RAT-Type", DataType_Octets, &VSAEncoder3GPPRATType{})
+	registerVSA(Vendor_3GPP, VSA_3GPPUserLocationInfo, "3GPP-User-Location-Info", DataType_Octets, &VSAEncoder3GPPUserLocationInfo{})
+	registerVSA(Vendor_3GPP, VSA_3GPPMSTimeZone, "3GPP-MS-TimeZone", DataType_Octets, &VSAEncoder3GPPMSTimeZone{})
+	registerVSA(Vendor_3GPP, VSA_3GPPCAMELChargingInfo, "3GPP-CAMEL-Charging-Info", DataType_Octets, octetsEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPPacketFilter, "3GPP-Packet-Filter", DataType_Octets, octetsEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPNegotiatedDSCP, "3GPP-Negotiated-DSCP", DataType_Octets, octetsEncoder)
+	registerVSA(Vendor_3GPP, VSA_3GPPAllocateIPType, "3GPP-Allocate-IP-Type", DataType_Octets, octetsEncoder)
+}
+
+// RATType is the radio access technology of 3GPP-RAT-Type
+type RATType uint8
+
+const (
+	RATType_Reserved      RATType = 0
+	RATType_UTRAN         RATType = 1
+	RATType_GERAN         RATType = 2
+	RATType_WLAN          RATType = 3
+	RATType_GAN           RATType = 4
+	RATType_HSPAEvolution RATType = 5
+	RATType_EUTRAN        RATType = 6
+	RATType_Virtual       RATType = 7
+	RATType_EUTRANNBIoT   RATType = 8
+	RATType_LTEM          RATType = 9
+	RATType_NR            RATType = 10
+	RATType_IEEE80216e    RATType = 101
+	RATType_3GPP2eHRPD    RATType = 102
+	RATType_3GPP2HRPD     RATType = 103
+	RATType_3GPP21xRTT    RATType = 104
+	RATType_3GPP2UMB      RATType = 105
+)
+
+var ratTypeName = map[RATType]string{
+	RATType_Reserved:      "Reserved",
+	RATType_UTRAN:         "UTRAN",
+	RATType_GERAN:         "GERAN",
+	RATType_WLAN:          "WLAN",
+	RATType_GAN:           "GAN",
+	RATType_HSPAEvolution: "HSPA-Evolution",
+	RATType_EUTRAN:        "EUTRAN",
+	RATType_Virtual:       "Virtual",
+	RATType_EUTRANNBIoT:   "EUTRAN-NB-IoT",
+	RATType_LTEM:          "LTE-M",
+	RATType_NR:            "NR",
+	RATType_IEEE80216e:    "IEEE-802.16e",
+	RATType_3GPP2eHRPD:    "3GPP2-eHRPD",
+	RATType_3GPP2HRPD:     "3GPP2-HRPD",
+	RATType_3GPP21xRTT:    "3GPP2-1xRTT",
+	RATType_3GPP2UMB:      "3GPP2-UMB",
+}
+
+func (r RATType) String() string {
+	if name, ok := ratTypeName[r]; ok {
+		return fmt.Sprintf("%s(%d)", name, r)
+	}
+	return fmt.Sprintf("unknown(%d)", r)
+}
+
+type VSAEncoder3GPPRATType struct{}
+
+func (e *VSAEncoder3GPPRATType) Encode(v *VSA) error {
+	rat, ok := v.Data.(RATType)
+	if !ok {
+		return errors.New("3GPP-RAT-Type vsa must be RATType")
+	}
+	v.Value = []byte{byte(rat)}
+	return nil
+}
+
+func (e *VSAEncoder3GPPRATType) Decode(v *VSA) error {
+	if len(v.Value) != 1 {
+		return fmt.Errorf("3GPP-RAT-Type vsa has invalid size %d", len(v.Value))
+	}
+	v.Data = RATType(v.Value[0])
+	return nil
+}
+
+// GeographicLocationType is the first octet of 3GPP-User-Location-Info
+type GeographicLocationType uint8
+
+const (
+	GeographicLocation_CGI     GeographicLocationType = 0
+	GeographicLocation_SAI     GeographicLocationType = 1
+	GeographicLocation_RAI     GeographicLocationType = 2
+	GeographicLocation_TAI     GeographicLocationType = 128
+	GeographicLocation_ECGI    GeographicLocationType = 129
+	GeographicLocation_TAIECGI GeographicLocationType = 130
+)
+
+/*
+UserLocationInfo is decoded 3GPP-User-Location-Info.
+Only fields of the location type are filled, location of unsupported
+types is kept in Raw as is
+*/
+type UserLocationInfo struct {
+	Type GeographicLocationType
+	MCC  string
+	MNC  string
+	// Location Area Code of CGI, SAI and RAI
+	LAC uint16
+	// Cell Identity of CGI
+	CI uint16
+	// Service Area Code of SAI
+	SAC uint16
+	// Routing Area Code of RAI
+	RAC uint8
+	// Tracking Area Code of TAI
+	TAC uint16
+	// E-UTRAN Cell Identifier of ECGI (28 bits)
+	ECI uint32
+	Raw []byte
+}
+
+// decodePLMN decodes MCC and MNC from 3 octets of swapped BCD digits
+func decodePLMN(b []byte) (mcc, mnc string, err error) {
+	digits := []byte{b[0] & 0x0f, b[0] >> 4, b[1] & 0x0f, b[2] & 0x0f, b[2] >> 4, b[1] >> 4}
+	for i, d := range digits {
+		if d > 9 && !(i == 5 && d == 0x0f) {
+			return "", "", fmt.Errorf("invalid PLMN digit %x", d)
+		}
+		digits[i] = '0' + d
+	}
+	mcc = string(digits[:3])
+	if digits[5] == '0'+0x0f {
+		mnc = string(digits[3:5])
+	} else {
+		mnc = string(digits[3:6])
+	}
+	return
+}
+
+// encodePLMN encodes MCC and MNC into 3 octets of swapped BCD digits
+func encodePLMN(mcc, mnc string) ([]byte, error) {
+	if len(mcc) != 3 || (len(mnc) != 2 && len(mnc) != 3) {
+		return nil, fmt.Errorf("invalid PLMN %s-%s", mcc, mnc)
+	}
+	digits := make([]byte, 6)
+	for i, c := range []byte(mcc + mnc) {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid PLMN %s-%s", mcc, mnc)
+		}
+		digits[i] = c - '0'
+	}
+	if len(mnc) == 2 {
+		digits[5] = 0x0f
+	}
+	return []byte{digits[1]<<4 | digits[0], digits[5]<<4 | digits[2], digits[4]<<4 | digits[3]}, nil
+}
+
+type VSAEncoder3GPPUserLocationInfo struct{}
+
+func (e *VSAEncoder3GPPUserLocationInfo) Encode(v *VSA) error {
+	uli, ok := v.Data.(*UserLocationInfo)
+	if !ok {
+		return errors.New("3GPP-User-Location-Info vsa must be *UserLocationInfo")
+	}
+	wire := []byte{byte(uli.Type)}
+	switch uli.Type {
+	case GeographicLocation_CGI, GeographicLocation_SAI, GeographicLocation_RAI,
+		GeographicLocation_TAI, GeographicLocation_ECGI, GeographicLocation_TAIECGI:
+		plmn, err := encodePLMN(uli.MCC, uli.MNC)
+		if err != nil {
+			return err
+		}
+		wire = append(wire, plmn...)
+		switch uli.Type {
+		case GeographicLocation_CGI:
+			wire = append(wire, byte(uli.LAC>>8), byte(uli.LAC), byte(uli.CI>>8), byte(uli.CI))
+		case GeographicLocation_SAI:
+			wire = append(wire, byte(uli.LAC>>8), byte(uli.LAC), byte(uli.SAC>>8), byte(uli.SAC))
+		case GeographicLocation_RAI:
+			wire = append(wire, byte(uli.LAC>>8), byte(uli.LAC), uli.RAC, 0xff)
+		case GeographicLocation_TAI:
+			wire = append(wire, byte(uli.TAC>>8), byte(uli.TAC))
+		case GeographicLocation_ECGI:
+			wire = binary.BigEndian.AppendUint32(wire, uli.ECI&0x0fffffff)
+		case GeographicLocation_TAIECGI:
+			wire = append(wire, byte(uli.TAC>>8), byte(uli.TAC))
+			wire = append(wire, plmn...)
+			wire = binary.BigEndian.AppendUint32(wire, uli.ECI&0x0fffffff)
+		}
+	default:
+		wire = append(wire, uli.Raw...)
+	}
+	if err := checkVSALength(wire); err != nil {
+		return err
+	}
+	v.Value = wire
+	return nil
+}
+
+func (e *VSAEncoder3GPPUserLocationInfo) Decode(v *VSA) error {
+	if len(v.Value) < 1 {
+		return errors.New("3GPP-User-Location-Info vsa is empty")
+	}
+	uli := &UserLocationInfo{Type: GeographicLocationType(v.Value[0])}
+	b := v.Value[1:]
+
+	var size int
+	switch uli.Type {
+	case GeographicLocation_CGI, GeographicLocation_SAI, GeographicLocation_ECGI:
+		size = 7
+	case GeographicLocation_RAI:
+		size = 6
+	case GeographicLocation_TAI:
+		size = 5
+	case GeographicLocation_TAIECGI:
+		size = 12
+	default:
+		uli.Raw = b
+		v.Data = uli
+		return nil
+	}
+	if len(b) < size {
+		return fmt.Errorf("3GPP-User-Location-Info vsa is too short for location type %d: %d bytes", uli.Type, len(b))
+	}
+
+	var err error
+	if uli.MCC, uli.MNC, err = decodePLMN(b); err != nil {
+		return err
+	}
+	switch uli.Type {
+	case GeographicLocation_CGI:
+		uli.LAC = binary.BigEndian.Uint16(b[3:])
+		uli.CI = binary.BigEndian.Uint16(b[5:])
+	case GeographicLocation_SAI:
+		uli.LAC = binary.BigEndian.Uint16(b[3:])
+		uli.SAC = binary.BigEndian.Uint16(b[5:])
+	case GeographicLocation_RAI:
+		uli.LAC = binary.BigEndian.Uint16(b[3:])
+		uli.RAC = b[5]
+	case GeographicLocation_TAI:
+		uli.TAC = binary.BigEndian.Uint16(b[3:])
+	case GeographicLocation_ECGI:
+		uli.ECI = binary.BigEndian.Uint32(b[3:]) & 0x0fffffff
+	case GeographicLocation_TAIECGI:
+		uli.TAC = binary.BigEndian.Uint16(b[3:])
+		uli.ECI = binary.BigEndian.Uint32(b[8:]) & 0x0fffffff
+	}
+	v.Data = uli
+	return nil
+}
+
+/*
+MSTimeZone is decoded 3GPP-MS-TimeZone: offset of the local time from UTC
+and daylight saving adjustment, see 3GPP TS 24.008 10.5.3.8
+*/
+type MSTimeZone struct {
+	Offset         time.Duration
+	DaylightSaving time.Duration
+}
+
+// Location returns fixed zone of the time zone offset
+func (tz MSTimeZone) Location() *time.Location {
+	return time.FixedZone("", int(tz.Offset/time.Second))
+}
+
+type VSAEncoder3GPPMSTimeZone struct{}
+
+func (e *VSAEncoder3GPPMSTimeZone) Encode(v *VSA) error {
+	tz, ok := v.Data.(MSTimeZone)
+	if !ok {
+		return errors.New("3GPP-MS-TimeZone vsa must be MSTimeZone")
+	}
+	quarters := tz.Offset / (15 * time.Minute)
+	var sign byte
+	if quarters < 0 {
+		sign = 0x08
+		quarters = -quarters
+	}
+	if quarters > 79 || tz.DaylightSaving < 0 || tz.DaylightSaving > 2*time.Hour {
+		return errors.New("3GPP-MS-TimeZone is out of range")
+	}
+	//semi-octets are swapped: tens in the low nibble, units in the high one
+	zone := byte(quarters%10)<<4 | byte(quarters/10) | sign
+	v.Value = []byte{zone, byte(tz.DaylightSaving / time.Hour)}
+	return nil
+}
+
+func (e *VSAEncoder3GPPMSTimeZone) Decode(v *VSA) error {
+	if len(v.Value) != 2 {
+		return fmt.Errorf("3GPP-MS-TimeZone vsa has invalid size %d", len(v.Value))
+	}
+	zone := v.Value[0]
+	quarters := time.Duration(zone&0x07)*10 + time.Duration(zone>>4)
+	tz := MSTimeZone{
+		Offset:         quarters * 15 * time.Minute,
+		DaylightSaving: time.Duration(v.Value[1]&0x03) * time.Hour,
+	}
+	if zone&0x08 != 0 {
+		tz.Offset = -tz.Offset
+	}
+	v.Data = tz
+	return nil
+}
+
+/*
+QoSProfile is decoded 3GPP-GPRS-Negotiated-QoS-Profile
+in form "<release indicator>-<hex encoded release specific QoS IE>"
+*/
+type QoSProfile struct {
+	Release string
+	Profile []byte
+}
+
+func (q QoSProfile) String() string {
+	return q.Release + "-" + strings.ToUpper(hex.EncodeToString(q.Profile))
+}
+
+type VSAEncoder3GPPQoSProfile struct{}
+
+func (e *VSAEncoder3GPPQoSProfile) Encode(v *VSA) error {
+	q, ok := v.Data.(QoSProfile)
+	if !ok {
+		return errors.New("3GPP-GPRS-Negotiated-QoS-Profile vsa must be QoSProfile")
+	}
+	if len(q.Release) != 2 {
+		return fmt.Errorf("invalid QoS profile release indicator %q", q.Release)
+	}
+	wire := []byte(q.String())
+	if err := checkVSALength(wire); err != nil {
+		return err
+	}
+	v.Value = wire
+	return nil
+}
+
+func (e *VSAEncoder3GPPQoSProfile) Decode(v *VSA) error {
+	str := string(v.Value)
+	if len(str) < 3 || str[2] != '-' {
+		return fmt.Errorf("invalid QoS profile %q", str)
+	}
+	profile, err := hex.DecodeString(str[3:])
+	if err != nil {
+		return fmt.Errorf("invalid QoS profile %q: %v", str, err)
+	}
+	v.Data = QoSProfile{Release: str[:2], Profile: profile}
+	return nil
+}
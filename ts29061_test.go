@@ -0,0 +1,131 @@
+package radius
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestVSA3GPP_Decode(t *testing.T) {
+	var (
+		a   *Attribute
+		err error
+	)
+	if a, err = NewVendorAttribute(Vendor_3GPP); err != nil {
+		t.Fatal(err)
+	}
+	a.AddAVPair(VSA_3GPPIMSI, []byte("250991234567890"))
+	a.AddAVPair(VSA_3GPPChargingID, []byte{0, 0, 0x30, 0x39})
+	a.AddAVPair(VSA_3GPPSGSNAddress, []byte{10, 0, 0, 1})
+	a.AddAVPair(VSA_3GPPRATType, []byte{6})
+	//TAI+ECGI mcc 250 mnc 99
+	a.AddAVPair(VSA_3GPPUserLocationInfo, []byte{130, 0x52, 0xf0, 0x99, 0x1f, 0x41, 0x52, 0xf0, 0x99, 0x01, 0x23, 0x45, 0x67})
+	a.AddAVPair(VSA_3GPPMSTimeZone, []byte{0x21, 0x00})
+	a.AddAVPair(VSA_3GPPGPRSNegotiatedQoSProfile, []byte("08-4A00000000"))
+	if err := a.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	a.Pairs = nil
+	a.Value = nil
+	if err := a.Decode(); err != nil {
+		t.Fatal(err)
+	}
+
+	if imsi, ok := a.Pairs[0].Data.(string); !ok || imsi != "250991234567890" {
+		t.Errorf("Expected IMSI 250991234567890 got %v", a.Pairs[0].Data)
+	}
+	if id, ok := a.Pairs[1].Data.(uint32); !ok || id != 12345 {
+		t.Errorf("Expected Charging-ID 12345 got %v", a.Pairs[1].Data)
+	}
+	if ip, ok := a.Pairs[2].Data.(net.IP); !ok || ip.String() != "10.0.0.1" {
+		t.Errorf("Expected SGSN-Address 10.0.0.1 got %v", a.Pairs[2].Data)
+	}
+	if rat, ok := a.Pairs[3].Data.(RATType); !ok || rat != RATType_EUTRAN {
+		t.Errorf("Expected %s got %v", RATType_EUTRAN, a.Pairs[3].Data)
+	}
+
+	uli, ok := a.Pairs[4].Data.(*UserLocationInfo)
+	if !ok {
+		t.Fatalf("Expected *UserLocationInfo got %T", a.Pairs[4].Data)
+	}
+	if uli.MCC != "250" || uli.MNC != "99" || uli.TAC != 0x1f41 || uli.ECI != 0x1234567 {
+		t.Errorf("Expected 250-99 TAC 0x1f41 ECI 0x1234567 got %+v", uli)
+	}
+
+	tz, ok := a.Pairs[5].Data.(MSTimeZone)
+	if !ok {
+		t.Fatalf("Expected MSTimeZone got %T", a.Pairs[5].Data)
+	}
+	if tz.Offset != 3*time.Hour || tz.DaylightSaving != 0 {
+		t.Errorf("Expected +3h got %+v", tz)
+	}
+
+	qos, ok := a.Pairs[6].Data.(QoSProfile)
+	if !ok {
+		t.Fatalf("Expected QoSProfile got %T", a.Pairs[6].Data)
+	}
+	if qos.Release != "08" || !bytes.Equal(qos.Profile, []byte{0x4a, 0, 0, 0, 0}) {
+		t.Errorf("Expected 08-4A00000000 got %s", qos)
+	}
+}
+
+func TestVSA3GPP_DecodeMalformed(t *testing.T) {
+	a, err := NewVendorAttribute(Vendor_3GPP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.AddAVPair(VSA_3GPPRATType, []byte{6, 0})
+	a.AddAVPair(VSA_3GPPIMSI, []byte("250991234567890"))
+	p := NewPacket(Code_AccountingRequest, []byte("ctrhtn"))
+	p.AddAttr(a)
+	if err := p.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &Packet{Wire: p.Wire}
+	if err := req.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	rat := req.VSA(Vendor_3GPP, VSA_3GPPRATType)
+	if rat == nil || rat.Data != nil || !bytes.Equal(rat.Value, []byte{6, 0}) {
+		t.Errorf("Expected raw 3GPP-RAT-Type 0600 got %+v", rat)
+	}
+	if imsi := req.VSA(Vendor_3GPP, VSA_3GPPIMSI); imsi == nil || imsi.Data != "250991234567890" {
+		t.Errorf("Expected IMSI 250991234567890 got %+v", imsi)
+	}
+}
+
+func TestVSA3GPP_Encode(t *testing.T) {
+	var (
+		a   *Attribute
+		err error
+	)
+	if a, err = NewVendorAttribute(Vendor_3GPP); err != nil {
+		t.Fatal(err)
+	}
+	uli := &UserLocationInfo{Type: GeographicLocation_ECGI, MCC: "310", MNC: "410", ECI: 0xabcdef1}
+	if err := a.AddVSA(VSA_3GPPUserLocationInfo, uli); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AddVSA(VSA_3GPPMSTimeZone, MSTimeZone{Offset: -(5*time.Hour + 30*time.Minute), DaylightSaving: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.AddVSA(VSA_3GPPRATType, "EUTRAN"); err == nil {
+		t.Errorf("Expected: err got: %v", a.Pairs[len(a.Pairs)-1].Value)
+	}
+
+	expected := []byte{129, 0x13, 0x00, 0x14, 0x0a, 0xbc, 0xde, 0xf1}
+	if !bytes.Equal(a.Pairs[0].Value, expected) {
+		t.Errorf("Expected %x got %x", expected, a.Pairs[0].Value)
+	}
+
+	v := &VSA{Value: a.Pairs[1].Value}
+	if err := (&VSAEncoder3GPPMSTimeZone{}).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	if tz := v.Data.(MSTimeZone); tz.Offset != -(5*time.Hour+30*time.Minute) || tz.DaylightSaving != time.Hour {
+		t.Errorf("Expected -5h30m +1h got %+v", tz)
+	}
+}
@@ -0,0 +1,181 @@
+package radius
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Occurs is how many times attribute may appear in packet as in attribute tables of RFCs
+type Occurs uint8
+
+const (
+	// Occurs_None - 0, attribute must not be present
+	Occurs_None Occurs = iota
+	// Occurs_ZeroOrOne - 0-1
+	Occurs_ZeroOrOne
+	// Occurs_One - 1, attribute must be present once
+	Occurs_One
+	// Occurs_Any - 0+
+	Occurs_Any
+)
+
+// short names for attribute tables
+const (
+	o0  = Occurs_None
+	o01 = Occurs_ZeroOrOne
+	o1  = Occurs_One
+	o0n = Occurs_Any
+)
+
+func (o Occurs) String() string {
+	switch o {
+	case Occurs_None:
+		return "0"
+	case Occurs_ZeroOrOne:
+		return "0-1"
+	case Occurs_One:
+		return "1"
+	case Occurs_Any:
+		return "0+"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(o))
+}
+
+func (o Occurs) allows(count int) bool {
+	switch o {
+	case Occurs_None:
+		return count == 0
+	case Occurs_ZeroOrOne:
+		return count <= 1
+	case Occurs_One:
+		return count == 1
+	}
+	return true
+}
+
+var (
+	// codeAttrOccurs is merged attribute tables by packet code
+	codeAttrOccurs = make(map[PacketType]map[AttributeType]Occurs)
+	// tableAttrs are attributes present in any table, other attributes are not checked
+	tableAttrs = make(map[AttributeType]bool)
+	// codeOccursFallback is code which table is used for attributes missing in table of the code
+	codeOccursFallback = make(map[PacketType]PacketType)
+)
+
+// registerOccurs adds attribute table of RFC, every row has Occurs for each of codes
+func registerOccurs(codes []PacketType, rows map[AttributeType][]Occurs) {
+	for t, occurs := range rows {
+		if len(occurs) != len(codes) {
+			panic(fmt.Sprintf("radius: attribute table row %s has %d columns, want %d", t, len(occurs), len(codes)))
+		}
+		tableAttrs[t] = true
+		for i, code := range codes {
+			if codeAttrOccurs[code] == nil {
+				codeAttrOccurs[code] = make(map[AttributeType]Occurs)
+			}
+			codeAttrOccurs[code][t] = occurs[i]
+		}
+	}
+}
+
+// AttributeOccurs returns how many times attribute may appear in packet of the code,
+// ok is false if tables don't restrict the attribute in the code
+func AttributeOccurs(code PacketType, t AttributeType) (o Occurs, ok bool) {
+	table, hasTable := codeAttrOccurs[code]
+	if !hasTable || !tableAttrs[t] {
+		return Occurs_Any, false
+	}
+	if o, ok := table[t]; ok {
+		return o, true
+	}
+	if fallback, ok := codeOccursFallback[code]; ok {
+		if o, ok := codeAttrOccurs[fallback][t]; ok {
+			return o, true
+		}
+	}
+	// attribute of other tables is not allowed in the code
+	return Occurs_None, true
+}
+
+/*
+Validate checks packet against attribute tables of rfc 2865, 2866, 2868, 2869, 3162 and 5176
+and requirements of attribute combinations:
+
+  - Access-Request has NAS-IP-Address, NAS-IPv6-Address or NAS-Identifier and exactly one
+    of User-Password, CHAP-Password or EAP-Message
+  - Accounting-Request has NAS-IP-Address, NAS-IPv6-Address or NAS-Identifier
+  - EAP-Message comes with exactly one Message-Authenticator (rfc 3579)
+  - Status-Server has Message-Authenticator (rfc 5997)
+
+Errors of all violations are joined.
+*/
+func (p *Packet) Validate() error {
+	var errs []error
+	var order []AttributeType
+	counts := make(map[AttributeType]int)
+	for _, a := range p.Attributes {
+		if counts[a.Type] == 0 {
+			order = append(order, a.Type)
+		}
+		counts[a.Type]++
+	}
+
+	for _, t := range order {
+		if o, ok := AttributeOccurs(p.Type, t); ok && !o.allows(counts[t]) {
+			errs = append(errs, fmt.Errorf("%s: %s occurs %d times, allowed %s", p.Type, t.label(), counts[t], o))
+		}
+	}
+	var missing []AttributeType
+	for t, o := range codeAttrOccurs[p.Type] {
+		if o == Occurs_One && counts[t] == 0 {
+			missing = append(missing, t)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	for _, t := range missing {
+		errs = append(errs, fmt.Errorf("%s: %s is missing", p.Type, t.label()))
+	}
+
+	hasNAS := counts[Attr_NASIPAddress]+counts[Attr_NASIPv6Address]+counts[Attr_NASIdentifier] > 0
+	switch p.Type {
+	case Code_AccessRequest:
+		if !hasNAS {
+			errs = append(errs, fmt.Errorf("%s: NAS-IP-Address, NAS-IPv6-Address or NAS-Identifier is missing", p.Type))
+		}
+		credentials := 0
+		for _, t := range []AttributeType{Attr_UserPassword, Attr_CHAPPassword, Attr_EAPMessage} {
+			if counts[t] > 0 {
+				credentials++
+			}
+		}
+		if credentials != 1 {
+			errs = append(errs, fmt.Errorf("%s: must have exactly one of User-Password, CHAP-Password or EAP-Message", p.Type))
+		}
+	case Code_AccountingRequest:
+		if !hasNAS {
+			errs = append(errs, fmt.Errorf("%s: NAS-IP-Address, NAS-IPv6-Address or NAS-Identifier is missing", p.Type))
+		}
+	case Code_StatusServer:
+		if counts[Attr_MessageAuthenticator] != 1 {
+			errs = append(errs, fmt.Errorf("%s: must have Message-Authenticator", p.Type))
+		}
+	}
+	if counts[Attr_EAPMessage] > 0 && counts[Attr_MessageAuthenticator] != 1 {
+		errs = append(errs, fmt.Errorf("%s: EAP-Message must come with exactly one Message-Authenticator", p.Type))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidationPolicy is what Server does with requests and replies which fail Packet.Validate
+type ValidationPolicy int
+
+const (
+	// ValidationPolicy_None doesn't validate requests and replies
+	ValidationPolicy_None ValidationPolicy = iota
+	// ValidationPolicy_Log logs violations, serves the request and sends the reply
+	ValidationPolicy_Log
+	// ValidationPolicy_Drop logs violations, silently discards the request and refuses to send
+	// the reply with error of ResponseWriter.Write
+	ValidationPolicy_Drop
+)
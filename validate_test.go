@@ -0,0 +1,127 @@
+package radius
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPacket_Validate(t *testing.T) {
+	p := NewPacket(Code_AccessRequest, []byte("ctrhtn"))
+	p.AddAttribute(Attr_UserName, "bob")
+	p.AddAttribute(Attr_NASIdentifier, "nas1")
+	p.AddAttribute(Attr_UserPassword, "secret")
+	if err := p.Validate(); err != nil {
+		t.Errorf("Expected valid Access-Request got %v", err)
+	}
+
+	p.AddAttribute(Attr_CHAPPassword, "chap")
+	p.AddAttribute(Attr_UserName, "alice")
+	p.AddAttribute(Attr_AcctStatusType, AcctStatusType_Start)
+	err := p.Validate()
+	for _, s := range []string{"User-Name occurs 2 times, allowed 0-1", "Acct-Status-Type occurs 1 times, allowed 0",
+		"exactly one of User-Password, CHAP-Password or EAP-Message"} {
+		if err == nil || !strings.Contains(err.Error(), s) {
+			t.Errorf("Expected %q in %v", s, err)
+		}
+	}
+
+	acct := NewPacket(Code_AccountingRequest, []byte("ctrhtn"))
+	acct.AddAttribute(Attr_NASIPAddress, net.IPv4(10, 0, 0, 1))
+	acct.AddAttribute(Attr_AcctStatusType, AcctStatusType_Start)
+	if err = acct.Validate(); err == nil || !strings.Contains(err.Error(), "Acct-Session-Id is missing") {
+		t.Errorf("Expected missing Acct-Session-Id got %v", err)
+	}
+
+	reject := &Packet{Type: Code_AccessReject}
+	reject.AddAttribute(Attr_ReplyMessage, "denied")
+	reject.AddAttribute(Attr_ReplyMessage, "try later")
+	if err = reject.Validate(); err != nil {
+		t.Errorf("Expected valid Access-Reject got %v", err)
+	}
+	reject.AddAttribute(Attr_SessionTimeout, uint32(10))
+	if err = reject.Validate(); err == nil {
+		t.Error("Expected Session-Timeout not allowed in Access-Reject")
+	}
+
+	coa := &Packet{Type: Code_CoARequest}
+	coa.AddAttribute(Attr_AcctSessionId, "abc")
+	coa.AddAttribute(Attr_SessionTimeout, uint32(3600))
+	coa.AddAttribute(Attr_FilterId, "gold")
+	if err = coa.Validate(); err != nil {
+		t.Errorf("Expected valid CoA-Request got %v", err)
+	}
+	nak := &Packet{Type: Code_CoANAK}
+	nak.AddAttribute(Attr_ErrorCause, ErrorCause_SessionContextNotFound)
+	nak.AddAttribute(Attr_FilterId, "gold")
+	var joined interface{ Unwrap() []error }
+	if err = nak.Validate(); !errors.As(err, &joined) || len(joined.Unwrap()) != 1 {
+		t.Errorf("Expected Filter-Id not allowed in CoA-NAK got %v", err)
+	}
+}
+
+func TestServer_ValidateReply(t *testing.T) {
+	secret := []byte("ctrhtn")
+	for _, c := range []struct {
+		policy ValidationPolicy
+		sent   bool
+	}{
+		{ValidationPolicy_None, true},
+		{ValidationPolicy_Log, true},
+		{ValidationPolicy_Drop, false},
+	} {
+		errs := make(chan error, 1)
+		s := &Server{
+			Validation: c.policy,
+			Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+				reject := &Packet{Type: Code_AccessReject, Secret: secret}
+				reject.AddAttribute(Attr_SessionTimeout, uint32(10))
+				errs <- w.Write(reject)
+			}),
+		}
+		addr, _ := startServer(t, s)
+		request := NewPacket(Code_AccessRequest, secret)
+		request.AddAttribute(Attr_UserName, "bob")
+		request.AddAttribute(Attr_NASIdentifier, "nas")
+		request.SetUserPassword("password")
+		_, err := ExchangePacket(request, addr, 1, 200*time.Millisecond)
+		if sent := err == nil; sent != c.sent {
+			t.Errorf("%d: Expected sent %v got %v", c.policy, c.sent, err)
+		}
+		if err := <-errs; (err == nil) != c.sent {
+			t.Errorf("%d: Expected error of Write %v got %v", c.policy, !c.sent, err)
+		}
+	}
+}
+
+func TestServer_ValidateEAPReply(t *testing.T) {
+	secret := []byte("ctrhtn")
+	errs := make(chan error, 1)
+	s := &Server{
+		Validation: ValidationPolicy_Drop,
+		Handler: HandlerFunc(func(ctx context.Context, w ResponseWriter, r *Request) {
+			challenge := &Packet{Type: Code_AccessChallenge, Secret: secret}
+			challenge.AddAttribute(Attr_EAPMessage, []byte{1, 2, 0, 6, 13, 0x20})
+			errs <- w.Write(challenge)
+		}),
+	}
+	addr, _ := startServer(t, s)
+	request := NewPacket(Code_AccessRequest, secret)
+	request.AddAttribute(Attr_UserName, "bob")
+	request.AddAttribute(Attr_NASIdentifier, "nas")
+	request.AddAttribute(Attr_EAPMessage, []byte{2, 1, 0, 8, 1, 'b', 'o', 'b'})
+	request.SignMessageAuthenticator = true
+	reply, err := ExchangePacket(request, addr, 1, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Errorf("Expected EAP reply to be sent got %v", err)
+	}
+	if reply.Type != Code_AccessChallenge || reply.Attr(Attr_MessageAuthenticator) == nil {
+		t.Errorf("Expected Access-Challenge with Message-Authenticator got %s", reply)
+	}
+}
@@ -0,0 +1,171 @@
+package radius
+
+import "fmt"
+
+type vsaInfo struct {
+	Encoder  VSAEncoderInterface
+	Name     string
+	DataType DataType
+}
+
+var (
+	vendorName      = make(map[uint32]string)
+	vsaTypeToInfo   = make(map[uint32]map[uint8]vsaInfo)
+	vsaNameToType   = make(map[uint32]map[string]uint8)
+	vsaTypeToValues = make(map[uint32]map[uint8]*attrValues)
+)
+
+func registerVendor(vendorId uint32, name string) {
+	vendorName[vendorId] = name
+}
+
+func registerVSA(vendorId uint32, vendorType uint8, name string, dataType DataType, encoder VSAEncoderInterface) {
+	if vsaTypeToInfo[vendorId] == nil {
+		vsaTypeToInfo[vendorId] = make(map[uint8]vsaInfo)
+		vsaNameToType[vendorId] = make(map[string]uint8)
+	}
+	vsaTypeToInfo[vendorId][vendorType] = vsaInfo{encoder, name, dataType}
+	vsaNameToType[vendorId][name] = vendorType
+}
+
+var dataTypeVSAEncoder = map[DataType]VSAEncoderInterface{
+	DataType_String:    &VSAEncoderString{},
+	DataType_Octets:    &VSAEncoderOctets{},
+	DataType_IPAddr:    &VSAEncoderAddress{},
+	DataType_Integer:   &VSAEncoderUint32{},
+	DataType_Integer64: &VSAEncoderUint64{},
+	DataType_Date:      &VSAEncoderDate{},
+	DataType_IPv6Addr:  &VSAEncoderIPv6Address{},
+	DataType_IfId:      &VSAEncoderOctets{},
+}
+
+// registerVSAType is called by generated code for every dictionary vendor attribute
+func registerVSAType(vendorId uint32, vendorType uint8, name string, dataType DataType) {
+	encoder, ok := dataTypeVSAEncoder[dataType]
+	if !ok {
+		panic("radius: no vsa encoder for data type " + string(dataType) + " of " + name)
+	}
+	registerVSA(vendorId, vendorType, name, dataType, encoder)
+}
+
+// registerVSAEnum makes decoding of the registered vendor attribute produce named values
+func registerVSAEnum(vendorId uint32, vendorType uint8, newValue func(uint32) interface{}) {
+	ai, ok := lookupVSA(vendorId, vendorType)
+	if !ok {
+		panic(fmt.Sprintf("radius: enum of unregistered vendor attribute %d/%d", vendorId, vendorType))
+	}
+	registerVSA(vendorId, vendorType, ai.Name, ai.DataType, &VSAEncoderEnum{ai.Encoder, newValue})
+}
+
+// registerVSAValue is called by generated code for every dictionary value of vendor attribute
+func registerVSAValue(vendorId uint32, vendorType uint8, name string, value uint32) {
+	if vsaTypeToValues[vendorId] == nil {
+		vsaTypeToValues[vendorId] = make(map[uint8]*attrValues)
+	}
+	if vsaTypeToValues[vendorId][vendorType] == nil {
+		vsaTypeToValues[vendorId][vendorType] = new(attrValues)
+	}
+	vsaTypeToValues[vendorId][vendorType].add(name, value)
+}
+
+// vsaValueString formats named value of vendor attribute as Name(value)
+func vsaValueString(vendorId uint32, vendorType uint8, value uint32) string {
+	return vsaTypeToValues[vendorId][vendorType].String(value)
+}
+
+func lookupVSA(vendorId uint32, vendorType uint8) (vsaInfo, bool) {
+	ai, ok := vsaTypeToInfo[vendorId][vendorType]
+	return ai, ok
+}
+
+// VendorName returns dictionary name of the vendor or empty string if vendor is unknown
+func VendorName(vendorId uint32) string {
+	return vendorName[vendorId]
+}
+
+// VSAName returns dictionary name of the vendor attribute or empty string if it is unknown
+func VSAName(vendorId uint32, vendorType uint8) string {
+	if ai, ok := lookupVSA(vendorId, vendorType); ok {
+		return ai.Name
+	}
+	return ""
+}
+
+// VSATypeByName returns type of the dictionary vendor attribute name
+func VSATypeByName(vendorId uint32, name string) (uint8, bool) {
+	vendorType, ok := vsaNameToType[vendorId][name]
+	return vendorType, ok
+}
+
+// NewVendorAttribute makes empty Vendor-Specific attribute of the vendor,
+// pairs are added with AddVSA or AddAVPair
+func NewVendorAttribute(vendorId uint32) (*Attribute, error) {
+	a, err := NewAttribute(Attr_VendorSpecific)
+	if err != nil {
+		return nil, err
+	}
+	a.Value = vendorId
+	return a, nil
+}
+
+// VendorId returns vendor id of Vendor-Specific attribute
+func (a *Attribute) VendorId() (uint32, error) {
+	if a.Type != Attr_VendorSpecific {
+		return 0, fmt.Errorf("attribute %s is not Vendor-Specific", a.Type)
+	}
+	vendorId, ok := a.Value.(uint32)
+	if !ok {
+		return 0, fmt.Errorf("vendor id must be uint32")
+	}
+	return vendorId, nil
+}
+
+// AddVSA encodes data with the dictionary encoder of the vendor attribute and appends it to pairs
+func (a *Attribute) AddVSA(vendorType uint8, data interface{}) error {
+	vendorId, err := a.VendorId()
+	if err != nil {
+		return err
+	}
+	ai, ok := lookupVSA(vendorId, vendorType)
+	if !ok {
+		return fmt.Errorf("can't determine encoder by vendor attribute: %d/%d", vendorId, vendorType)
+	}
+	vsa := &VSA{VendorType: vendorType, Data: data}
+	if err = ai.Encoder.Encode(vsa); err != nil {
+		return fmt.Errorf("vsa %s: %v", ai.Name, err)
+	}
+	a.Pairs = append(a.Pairs, vsa)
+	return nil
+}
+
+// VSA returns last vendor attribute of given vendor and type or nil if packet doesn't contain it
+func (p *Packet) VSA(vendorId uint32, vendorType uint8) *VSA {
+	var out *VSA
+	for _, a := range p.Attrs(Attr_VendorSpecific) {
+		if id, ok := a.Value.(uint32); !ok || id != vendorId {
+			continue
+		}
+		for _, vsa := range a.Pairs {
+			if vsa.VendorType == vendorType {
+				out = vsa
+			}
+		}
+	}
+	return out
+}
+
+// VSAs returns all vendor attributes of given vendor and type in order of appearance
+func (p *Packet) VSAs(vendorId uint32, vendorType uint8) []*VSA {
+	var out []*VSA
+	for _, a := range p.Attrs(Attr_VendorSpecific) {
+		if id, ok := a.Value.(uint32); !ok || id != vendorId {
+			continue
+		}
+		for _, vsa := range a.Pairs {
+			if vsa.VendorType == vendorType {
+				out = append(out, vsa)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,194 @@
+package radius
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// VSAEncoderInterface converts vendor attribute value between VSA.Value (wire) and VSA.Data
+type VSAEncoderInterface interface {
+	Encode(v *VSA) error
+	Decode(v *VSA) error
+}
+
+func checkVSALength(wire []byte) error {
+	if len(wire) > 253-8 {
+		return errors.New("encoded vsa is too long")
+	}
+	return nil
+}
+
+type VSAEncoderOctets struct{}
+
+func (e *VSAEncoderOctets) Encode(v *VSA) error {
+	wire, ok := v.Data.([]byte)
+	if !ok {
+		return errors.New("octets vsa must be []byte")
+	}
+	if err := checkVSALength(wire); err != nil {
+		return err
+	}
+	v.Value = wire
+	return nil
+}
+
+func (e *VSAEncoderOctets) Decode(v *VSA) error {
+	v.Data = v.Value
+	return nil
+}
+
+type VSAEncoderString struct{}
+
+func (e *VSAEncoderString) Encode(v *VSA) error {
+	var wire []byte
+	switch data := v.Data.(type) {
+	case []byte:
+		wire = data
+	case string:
+		wire = []byte(data)
+	default:
+		return errors.New("text vsa must be string or []byte")
+	}
+	if err := checkVSALength(wire); err != nil {
+		return err
+	}
+	v.Value = wire
+	return nil
+}
+
+func (e *VSAEncoderString) Decode(v *VSA) error {
+	v.Data = string(v.Value)
+	return nil
+}
+
+type VSAEncoderUint32 struct{}
+
+func (e *VSAEncoderUint32) Encode(v *VSA) error {
+	integer, ok := uint32Value(v.Data)
+	if !ok {
+		return errors.New("integer vsa must be uint32")
+	}
+	v.Value = make([]byte, 4)
+	binary.BigEndian.PutUint32(v.Value, integer)
+	return nil
+}
+
+func (e *VSAEncoderUint32) Decode(v *VSA) error {
+	if len(v.Value) != 4 {
+		return fmt.Errorf("integer vsa has invalid size %d", len(v.Value))
+	}
+	v.Data = binary.BigEndian.Uint32(v.Value)
+	return nil
+}
+
+type VSAEncoderUint64 struct{}
+
+func (e *VSAEncoderUint64) Encode(v *VSA) error {
+	integer, ok := v.Data.(uint64)
+	if !ok {
+		return errors.New("integer64 vsa must be uint64")
+	}
+	v.Value = binary.BigEndian.AppendUint64(nil, integer)
+	return nil
+}
+
+func (e *VSAEncoderUint64) Decode(v *VSA) error {
+	if len(v.Value) != 8 {
+		return fmt.Errorf("integer64 vsa has invalid size %d", len(v.Value))
+	}
+	v.Data = binary.BigEndian.Uint64(v.Value)
+	return nil
+}
+
+type VSAEncoderDate struct{}
+
+func (e *VSAEncoderDate) Encode(v *VSA) error {
+	date, ok := v.Data.(time.Time)
+	if !ok {
+		return errors.New("date vsa must be time.Time")
+	}
+	if date.Unix() < 0 || date.Unix() > math.MaxUint32 {
+		return errors.New("date vsa is out of range")
+	}
+	v.Value = binary.BigEndian.AppendUint32(nil, uint32(date.Unix()))
+	return nil
+}
+
+func (e *VSAEncoderDate) Decode(v *VSA) error {
+	if len(v.Value) != 4 {
+		return fmt.Errorf("date vsa has invalid size %d", len(v.Value))
+	}
+	v.Data = time.Unix(int64(binary.BigEndian.Uint32(v.Value)), 0).UTC()
+	return nil
+}
+
+type VSAEncoderAddress struct{}
+
+func (e *VSAEncoderAddress) Encode(v *VSA) error {
+	ip, ok := v.Data.(net.IP)
+	if !ok {
+		return errors.New("address vsa must be net.IP")
+	}
+	if ip = ip.To4(); ip == nil {
+		return errors.New("address vsa must be an IPv4 net.IP")
+	}
+	v.Value = []byte(ip)
+	return nil
+}
+
+func (e *VSAEncoderAddress) Decode(v *VSA) error {
+	if len(v.Value) != net.IPv4len {
+		return errors.New("address vsa has invalid size")
+	}
+	v.Data = net.IP(string(v.Value))
+	return nil
+}
+
+type VSAEncoderIPv6Address struct{}
+
+func (e *VSAEncoderIPv6Address) Encode(v *VSA) error {
+	ip, ok := v.Data.(net.IP)
+	if !ok {
+		return errors.New("ipv6 address vsa must be net.IP")
+	}
+	if ip.To4() != nil {
+		return errors.New("ipv6 address vsa must be an IPv6 net.IP")
+	}
+	if ip = ip.To16(); ip == nil {
+		return errors.New("ipv6 address vsa must be an IPv6 net.IP")
+	}
+	v.Value = []byte(ip)
+	return nil
+}
+
+func (e *VSAEncoderIPv6Address) Decode(v *VSA) error {
+	if len(v.Value) != net.IPv6len {
+		return errors.New("ipv6 address vsa has invalid size")
+	}
+	v.Data = net.IP(string(v.Value))
+	return nil
+}
+
+// VSAEncoderEnum makes named values of enumerated vendor attribute from integers decoded by Encoder
+type VSAEncoderEnum struct {
+	Encoder VSAEncoderInterface
+	New     func(uint32) interface{}
+}
+
+func (e *VSAEncoderEnum) Encode(v *VSA) error {
+	return e.Encoder.Encode(v)
+}
+
+func (e *VSAEncoderEnum) Decode(v *VSA) error {
+	if err := e.Encoder.Decode(v); err != nil {
+		return err
+	}
+	if integer, ok := v.Data.(uint32); ok {
+		v.Data = e.New(integer)
+	}
+	return nil
+}